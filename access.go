@@ -0,0 +1,97 @@
+package drupal
+
+import (
+	"strconv"
+
+	"github.com/phayes/errors"
+)
+
+// accessResult is the result of an access check as printed by php.
+// Missing is "entity" or "user" if the entity or user to check could not be loaded.
+type accessResult struct {
+	Missing string `json:"missing"`
+	Access  bool   `json:"access"`
+}
+
+// phpLoadAccount returns php code that loads a user into $account, printing an accessResult and returning if it does not exist
+func phpLoadAccount(uid int) string {
+	return "$account = \\Drupal::entityTypeManager()->getStorage('user')->load(" + strconv.Itoa(uid) + "); if (!$account) { print json_encode(array('missing' => 'user', 'access' => FALSE)); return; } "
+}
+
+// checkAccess runs php code that prints an accessResult, and converts missing entities or users into errors
+func (s Site) checkAccess(phpCode string, entityType string, id interface{}, uid int) (bool, error) {
+	var result accessResult
+	err := s.phpEval(phpCode, &result)
+	if err != nil {
+		return false, err
+	}
+
+	err = missingError(result.Missing, entityType, id, uid)
+	if err != nil {
+		return false, err
+	}
+	return result.Access, nil
+}
+
+// missingError returns ErrEntityNotFound or ErrUserNotFound if missing is "entity" or "user"
+func missingError(missing string, entityType string, id interface{}, uid int) error {
+	switch missing {
+	case "entity":
+		return errors.Wrapf(ErrEntityNotFound, "Could not load %v %v", entityType, id)
+	case "user":
+		return errors.Wrapf(ErrUserNotFound, "Could not load user %v", uid)
+	}
+	return nil
+}
+
+// GetEntityAccessResult checks if a user may perform an operation (eg "view", "update", "delete") on an entity.
+// Returns ErrEntityNotFound or ErrUserNotFound if the entity or user does not exist.
+func (s Site) GetEntityAccessResult(entityType string, id int, operation string, uid int) (bool, error) {
+	phpCode := phpLoadAccount(uid) +
+		"$entity = \\Drupal::entityTypeManager()->getStorage(" + phpString(entityType) + ")->load(" + strconv.Itoa(id) + "); if (!$entity) { print json_encode(array('missing' => 'entity', 'access' => FALSE)); return; } " +
+		"print json_encode(array('missing' => '', 'access' => \\Drupal::entityTypeManager()->getAccessControlHandler(" + phpString(entityType) + ")->access($entity, " + phpString(operation) + ", $account)));"
+
+	return s.checkAccess(phpCode, entityType, id, uid)
+}
+
+// GetEntityCreateAccess checks if a user may create entities of a bundle.
+// Returns ErrUserNotFound if the user does not exist.
+func (s Site) GetEntityCreateAccess(entityType, bundle string, uid int) (bool, error) {
+	phpCode := phpLoadAccount(uid) +
+		"print json_encode(array('missing' => '', 'access' => \\Drupal::entityTypeManager()->getAccessControlHandler(" + phpString(entityType) + ")->createAccess(" + phpString(bundle) + ", $account)));"
+
+	return s.checkAccess(phpCode, entityType, bundle, uid)
+}
+
+// GetEntityOperations checks which operations a user may perform on an entity.
+// The "view", "update" and "delete" operations are always checked. Any additional operations the entity type's
+// list builder provides to the user (eg "translate" or "revisions") are included as allowed.
+// Returns ErrEntityNotFound or ErrUserNotFound if the entity or user does not exist.
+func (s Site) GetEntityOperations(entityType string, id int, uid int) (map[string]bool, error) {
+	phpCode := phpLoadAccount(uid) +
+		"$manager = \\Drupal::entityTypeManager(); " +
+		"$entity = $manager->getStorage(" + phpString(entityType) + ")->load(" + strconv.Itoa(id) + "); if (!$entity) { print json_encode(array('missing' => 'entity', 'access' => FALSE)); return; } " +
+		"$operations = array(); " +
+		"foreach (array('view', 'update', 'delete') as $operation) { $operations[$operation] = $entity->access($operation, $account); } " +
+		"if ($manager->hasHandler(" + phpString(entityType) + ", 'list_builder')) { " +
+		"$switcher = \\Drupal::service('account_switcher'); $switcher->switchTo($account); " +
+		"foreach (array_keys($manager->getListBuilder(" + phpString(entityType) + ")->getOperations($entity)) as $operation) { if (!isset($operations[$operation])) { $operations[$operation] = TRUE; } } " +
+		"$switcher->switchBack(); " +
+		"} " +
+		"print json_encode(array('missing' => '', 'operations' => $operations));"
+
+	var result struct {
+		Missing    string          `json:"missing"`
+		Operations map[string]bool `json:"operations"`
+	}
+	err := s.phpEval(phpCode, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	err = missingError(result.Missing, entityType, id, uid)
+	if err != nil {
+		return nil, err
+	}
+	return result.Operations, nil
+}
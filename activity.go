@@ -0,0 +1,97 @@
+package drupal
+
+import (
+	"strconv"
+	"time"
+)
+
+// ActivityItem is a single recent change to an entity
+type ActivityItem struct {
+	Type       string // "created" if the entity has not changed since it was created, otherwise "updated"
+	EntityType string
+	EntityID   int
+	Title      string
+	UID        int // The owner of the entity, or 0 if the entity type has no owner
+	Timestamp  time.Time
+}
+
+// GetRecentContent gets the most recently created or changed entities across several entity types, newest first.
+// Entity types are sorted by their "changed" field if they have one, otherwise by their "created" field. Entity types with neither are skipped.
+func (s Site) GetRecentContent(entityTypes []string, limit int) ([]ActivityItem, error) {
+	phpTypes, err := phpValue(entityTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	phpCode := "$items = array(); " +
+		"foreach (" + phpTypes + " as $entityType) { " +
+		"$fields = \\Drupal::service('entity_field.manager')->getBaseFieldDefinitions($entityType); " +
+		"$sort = isset($fields['changed']) ? 'changed' : (isset($fields['created']) ? 'created' : NULL); " +
+		"if (!$sort) { continue; } " +
+		"$storage = \\Drupal::entityTypeManager()->getStorage($entityType); " +
+		"$query = $storage->getQuery()->accessCheck(FALSE)->sort($sort, 'DESC'); "
+	if limit > 0 {
+		phpCode += "$query->range(0, " + strconv.Itoa(limit) + "); "
+	}
+	phpCode += "foreach ($storage->loadMultiple($query->execute()) as $entity) { " +
+		"$timestamp = (int) $entity->get($sort)->value; " +
+		"$created = isset($fields['created']) ? (int) $entity->get('created')->value : $timestamp; " +
+		"$uid = $entity instanceof \\Drupal\\user\\EntityOwnerInterface ? (int) $entity->getOwnerId() : 0; " +
+		"$items[] = array('type' => $created == $timestamp ? 'created' : 'updated', 'entity_type' => $entityType, 'entity_id' => (int) $entity->id(), 'title' => (string) $entity->label(), 'uid' => $uid, 'timestamp' => $timestamp); " +
+		"} " +
+		"} " +
+		"usort($items, function ($a, $b) { return $b['timestamp'] - $a['timestamp']; }); "
+	if limit > 0 {
+		phpCode += "$items = array_slice($items, 0, " + strconv.Itoa(limit) + "); "
+	}
+	phpCode += "print json_encode($items);"
+
+	var itemsJSON []struct {
+		Type       string `json:"type"`
+		EntityType string `json:"entity_type"`
+		EntityID   int    `json:"entity_id"`
+		Title      string `json:"title"`
+		UID        int    `json:"uid"`
+		Timestamp  int64  `json:"timestamp"`
+	}
+	err = s.phpEval(phpCode, &itemsJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	items := []ActivityItem{}
+	for _, item := range itemsJSON {
+		items = append(items, ActivityItem{
+			Type:       item.Type,
+			EntityType: item.EntityType,
+			EntityID:   item.EntityID,
+			Title:      item.Title,
+			UID:        item.UID,
+			Timestamp:  time.Unix(item.Timestamp, 0),
+		})
+	}
+	return items, nil
+}
+
+// GetRecentUsers gets the most recently created user accounts, newest first. The anonymous user is never included.
+func (s Site) GetRecentUsers(limit int) ([]User, error) {
+	phpCode := phpUserJSON +
+		"$storage = \\Drupal::entityTypeManager()->getStorage('user'); " +
+		"$query = $storage->getQuery()->accessCheck(FALSE)->condition('uid', 0, '>')->sort('created', 'DESC'); "
+	if limit > 0 {
+		phpCode += "$query->range(0, " + strconv.Itoa(limit) + "); "
+	}
+	phpCode += "print json_encode(array_values(array_map($userJSON, $storage->loadMultiple($query->execute()))));"
+
+	var usersJSON []userJSON
+	err := s.phpEval(phpCode, &usersJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	users := []User{}
+	for _, user := range usersJSON {
+		users = append(users, user.user())
+	}
+	return users, nil
+}
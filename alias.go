@@ -0,0 +1,64 @@
+package drupal
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/phayes/errors"
+)
+
+// DrushAlias is a drush site alias
+type DrushAlias struct {
+	Name  string            `json:"-"` // Alias name, including the leading "@"
+	Root  string            `json:"root"`
+	URI   string            `json:"uri"`
+	Host  string            `json:"host"`
+	User  string            `json:"user"`
+	SSH   map[string]string `json:"ssh"`
+	Paths map[string]string `json:"paths"`
+}
+
+// GetSiteAliases gets all drush site aliases available to the site, sorted by name
+func (s Site) GetSiteAliases() ([]DrushAlias, error) {
+	output, _, errs := s.Drush("site-alias", "--format=json")
+	if errs != nil {
+		return nil, errs
+	}
+
+	aliases := []DrushAlias{}
+	output = strings.TrimSpace(output)
+	if output == "" || output == "[]" {
+		return aliases, nil
+	}
+
+	var aliasMap map[string]DrushAlias
+	err := json.Unmarshal([]byte(output), &aliasMap)
+	if err != nil {
+		return nil, errors.Wraps(err, "Error reading drush site aliases")
+	}
+
+	for name, alias := range aliasMap {
+		alias.Name = name
+		aliases = append(aliases, alias)
+	}
+	sort.Slice(aliases, func(i, j int) bool { return aliases[i].Name < aliases[j].Name })
+
+	return aliases, nil
+}
+
+// GetSiteAlias gets a single drush site alias by name. The leading "@" is optional.
+func (s Site) GetSiteAlias(name string) (*DrushAlias, error) {
+	aliases, err := s.GetSiteAliases()
+	if err != nil {
+		return nil, err
+	}
+
+	name = "@" + strings.TrimPrefix(name, "@")
+	for _, alias := range aliases {
+		if alias.Name == name {
+			return &alias, nil
+		}
+	}
+	return nil, errors.Newf("Drush site alias %v not found", name)
+}
@@ -0,0 +1,57 @@
+package drupal
+
+import (
+	"strconv"
+)
+
+// AMPStatus describes where the AMP module is in use
+type AMPStatus struct {
+	Enabled   bool     `json:"enabled"`    // Whether AMP is enabled for at least one bundle
+	Types     []string `json:"types"`      // Bundles with AMP enabled, as "entity_type.bundle" (eg "node.article")
+	ThemeName string   `json:"theme_name"` // Theme used to render AMP pages
+}
+
+// GetAMPStatus gets the bundles that have AMP enabled, and the AMP theme.
+// Returns ErrModuleNotEnabled if the amp module is not enabled.
+func (s Site) GetAMPStatus() (*AMPStatus, error) {
+	err := s.requireModule("amp")
+	if err != nil {
+		return nil, err
+	}
+
+	// A bundle has AMP enabled when it's "amp" view display is enabled
+	phpCode := phpListConfig("core.entity_view_display.") +
+		"$types = array(); foreach ($configs as $data) { if ($data['mode'] == 'amp' && $data['status']) { $types[] = $data['targetEntityType'] . '.' . $data['bundle']; } } " +
+		"print json_encode(array('enabled' => !empty($types), 'types' => $types, 'theme_name' => (string) \\Drupal::config('amp.theme')->get('amptheme')));"
+
+	var status AMPStatus
+	err = s.phpEval(phpCode, &status)
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// EnableAMPForType enables AMP for a bundle by enabling it's "amp" view display, creating the display if needed.
+// Returns ErrModuleNotEnabled if the amp module is not enabled.
+func (s Site) EnableAMPForType(entityType, bundle string) error {
+	return s.setAMPDisplayStatus(entityType, bundle, true)
+}
+
+// DisableAMPForType disables AMP for a bundle by disabling it's "amp" view display.
+// Returns ErrModuleNotEnabled if the amp module is not enabled.
+func (s Site) DisableAMPForType(entityType, bundle string) error {
+	return s.setAMPDisplayStatus(entityType, bundle, false)
+}
+
+func (s Site) setAMPDisplayStatus(entityType, bundle string, enabled bool) error {
+	err := s.requireModule("amp")
+	if err != nil {
+		return err
+	}
+
+	phpCode := "$repository = \\Drupal::service('entity_display.repository'); " +
+		"$display = method_exists($repository, 'getViewDisplay') ? $repository->getViewDisplay(" + phpString(entityType) + ", " + phpString(bundle) + ", 'amp') : entity_get_display(" + phpString(entityType) + ", " + phpString(bundle) + ", 'amp'); " +
+		"$display->setStatus(" + strconv.FormatBool(enabled) + ")->save();"
+	return s.phpEval(phpCode, nil)
+}
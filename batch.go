@@ -0,0 +1,21 @@
+package drupal
+
+// BatchProcess processes a pending batch using "drush batch-process"
+func (s Site) BatchProcess(batchSetID string) (DrushMessages, error) {
+	_, messages, errs := s.Drush("batch-process", batchSetID)
+	return messages, errs
+}
+
+// GetPendingBatches gets the IDs of all batches stored in the batch table, which have not yet finished processing
+func (s Site) GetPendingBatches() ([]int, error) {
+	phpCode := "$database = \\Drupal::database(); " +
+		"$ids = $database->schema()->tableExists('batch') ? $database->select('batch', 'b')->fields('b', array('bid'))->orderBy('bid')->execute()->fetchCol() : array(); " +
+		"print json_encode(array_map('intval', $ids));"
+
+	var ids []int
+	err := s.phpEval(phpCode, &ids)
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
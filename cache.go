@@ -0,0 +1,174 @@
+package drupal
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/phayes/errors"
+)
+
+// GetCacheTagsForEntity gets the cache tags of an entity
+func (s Site) GetCacheTagsForEntity(entityType string, id int) ([]string, error) {
+	var tags *[]string
+	err := s.phpEval(phpLoadEntity(entityType, id)+"print json_encode($entity->getCacheTags());", &tags)
+	if err != nil {
+		return nil, err
+	}
+	if tags == nil {
+		return nil, errors.Wrapf(ErrEntityNotFound, "Could not load %v %v", entityType, id)
+	}
+	return *tags, nil
+}
+
+// GetCacheContexts gets the IDs of all registered cache contexts (eg "url.path", "user.roles")
+func (s Site) GetCacheContexts() ([]string, error) {
+	var contexts []string
+	err := s.phpEval("print json_encode(\\Drupal::service('cache_contexts_manager')->getAll());", &contexts)
+	if err != nil {
+		return nil, err
+	}
+	return contexts, nil
+}
+
+// InvalidateCacheTags invalidates the given cache tags
+func (s Site) InvalidateCacheTags(tags ...string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	phpTags, err := phpValue(tags)
+	if err != nil {
+		return err
+	}
+	return s.phpEval("\\Drupal::service('cache_tags.invalidator')->invalidateTags("+phpTags+");", nil)
+}
+
+// Cache bins provided by drupal core, for use with ClearCacheBin
+const (
+	CacheBinDefault   = "default"
+	CacheBinRender    = "render"
+	CacheBinDynamic   = "dynamic_page_cache"
+	CacheBinDiscovery = "discovery"
+	CacheBinBootstrap = "bootstrap"
+	CacheBinConfig    = "config"
+	CacheBinEntity    = "entity"
+)
+
+// ClearCacheBin deletes all items in a single cache bin, without rebuilding all caches
+func (s Site) ClearCacheBin(bin string) error {
+	return s.phpEval("\\Drupal::cache("+phpString(bin)+")->deleteAll();", nil)
+}
+
+// ClearAllCacheBins rebuilds all caches using "drush cache-rebuild"
+func (s Site) ClearAllCacheBins() error {
+	_, _, errs := s.Drush("cache-rebuild")
+	return errs
+}
+
+// GetCachedRouteCount gets the number of route lookups cached in the data cache bin.
+// Only the database cache backend is supported.
+func (s Site) GetCachedRouteCount() (int, error) {
+	phpCode := "$database = \\Drupal::database(); " +
+		"print json_encode($database->schema()->tableExists('cache_data') ? (int) $database->select('cache_data', 'c')->condition('cid', $database->escapeLike('route:') . '%', 'LIKE')->countQuery()->execute()->fetchField() : 0);"
+
+	var count int
+	err := s.phpEval(phpCode, &count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// WarmRouteCache rebuilds the router, so the first request after a deployment does not have to
+func (s Site) WarmRouteCache() error {
+	return s.phpEval("\\Drupal::service('router.builder')->rebuild();", nil)
+}
+
+// GetCacheStatistics gets statistics for a cache bin.
+// If the bin's cache backend reports statistics (eg hits and misses) through a getStatistics() method, these are returned.
+// Otherwise, for the database cache backend, the number of "items" and "expired" items in the bin are returned.
+func (s Site) GetCacheStatistics(bin string) (map[string]int, error) {
+	phpCode := "$backend = \\Drupal::cache(" + phpString(bin) + "); " +
+		"if (method_exists($backend, 'getStatistics')) { print json_encode((object) array_map('intval', $backend->getStatistics())); return; } " +
+		"if (!($backend instanceof \\Drupal\\Core\\Cache\\DatabaseBackend)) { print json_encode(NULL); return; } " +
+		"$database = \\Drupal::database(); $table = 'cache_' . " + phpString(bin) + "; " +
+		"if (!$database->schema()->tableExists($table)) { print json_encode(array('items' => 0, 'expired' => 0)); return; } " +
+		"$items = $database->select($table)->countQuery()->execute()->fetchField(); " +
+		"$expired = $database->select($table)->condition('expire', -1, '<>')->condition('expire', \\Drupal::time()->getRequestTime(), '<')->countQuery()->execute()->fetchField(); " +
+		"print json_encode(array('items' => (int) $items, 'expired' => (int) $expired));"
+
+	var statistics map[string]int
+	err := s.phpEval(phpCode, &statistics)
+	if err != nil {
+		return nil, err
+	}
+	if statistics == nil {
+		return nil, errors.Newf("Cache backend for bin %v does not support statistics", bin)
+	}
+	return statistics, nil
+}
+
+// CacheTagEvent is a logged invalidation of cache tags
+type CacheTagEvent struct {
+	Tags      []string
+	Timestamp time.Time
+	Reason    string
+}
+
+// GetCacheTagInvalidationLog gets the most recent cache tag invalidations logged to the database log, newest first.
+// Drupal core does not log invalidations itself, so this reads dblog entries of type "cache_tags", as written by
+// cache tag logging modules, with the invalidated tags as a comma separated "@tags" placeholder.
+// Returns ErrModuleNotEnabled if the dblog module is not enabled.
+func (s Site) GetCacheTagInvalidationLog(limit int) ([]CacheTagEvent, error) {
+	err := s.requireModule("dblog")
+	if err != nil {
+		return nil, err
+	}
+
+	phpCode := "$query = \\Drupal::database()->select('watchdog', 'w')->fields('w', array('message', 'variables', 'timestamp'))->condition('type', 'cache_tags')->orderBy('wid', 'DESC'); "
+	if limit > 0 {
+		phpCode += "$query->range(0, " + strconv.Itoa(limit) + "); "
+	}
+	phpCode += "$events = array(); " +
+		"foreach ($query->execute() as $row) { " +
+		"$variables = $row->variables ? unserialize($row->variables) : array(); " +
+		"$tags = isset($variables['@tags']) ? array_values(array_filter(array_map('trim', explode(',', $variables['@tags'])))) : array(); " +
+		"$events[] = array('tags' => $tags, 'timestamp' => (int) $row->timestamp, 'reason' => strip_tags((string) new \\Drupal\\Core\\StringTranslation\\TranslatableMarkup($row->message, is_array($variables) ? $variables : array()))); " +
+		"} " +
+		"print json_encode($events);"
+
+	var logged []struct {
+		Tags      []string `json:"tags"`
+		Timestamp int64    `json:"timestamp"`
+		Reason    string   `json:"reason"`
+	}
+	err = s.phpEval(phpCode, &logged)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []CacheTagEvent{}
+	for _, event := range logged {
+		events = append(events, CacheTagEvent{Tags: event.Tags, Timestamp: time.Unix(event.Timestamp, 0), Reason: event.Reason})
+	}
+	return events, nil
+}
+
+// GetMostInvalidatedCacheTags gets the cache tags that have been invalidated the most times, most invalidated first.
+// Invalidation counts are read from the cachetags table used by the database cache tags checksum.
+func (s Site) GetMostInvalidatedCacheTags(limit int) ([]string, error) {
+	phpCode := "$database = \\Drupal::database(); " +
+		"if (!$database->schema()->tableExists('cachetags')) { print json_encode(array()); return; } " +
+		"$query = $database->select('cachetags', 'c')->fields('c', array('tag'))->orderBy('invalidations', 'DESC')->orderBy('tag'); "
+	if limit > 0 {
+		phpCode += "$query->range(0, " + strconv.Itoa(limit) + "); "
+	}
+	phpCode += "print json_encode($query->execute()->fetchCol());"
+
+	var tags []string
+	err := s.phpEval(phpCode, &tags)
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
@@ -0,0 +1,91 @@
+package drupal
+
+import (
+	"encoding/json"
+
+	"github.com/phayes/errors"
+)
+
+// DrushCommand is a drush command available to a site
+type DrushCommand struct {
+	Name        string
+	Aliases     []string
+	Description string
+	Arguments   map[string]string // Argument names mapped to their descriptions
+	Options     map[string]string // Option names mapped to their descriptions
+}
+
+// GetDrushCommandList gets the drush commands available to the site using "drush list --format=json"
+func (s Site) GetDrushCommandList() ([]DrushCommand, error) {
+	output, _, errs := s.Drush("list", "--format=json")
+	if errs != nil {
+		return nil, errs
+	}
+	return parseDrushCommandList([]byte(output))
+}
+
+// HasDrushCommand checks if a drush command, or a command alias, is available to the site
+func (s Site) HasDrushCommand(command string) (bool, error) {
+	commands, err := s.GetDrushCommandList()
+	if err != nil {
+		return false, err
+	}
+
+	for _, drushCommand := range commands {
+		if drushCommand.Name == command {
+			return true, nil
+		}
+		for _, alias := range drushCommand.Aliases {
+			if alias == command {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// parseDrushCommandList parses the output of "drush list --format=json"
+func parseDrushCommandList(output []byte) ([]DrushCommand, error) {
+	type input struct {
+		Description string `json:"description"`
+	}
+	var list struct {
+		Commands []struct {
+			Name        string   `json:"name"`
+			Aliases     []string `json:"aliases"`
+			Description string   `json:"description"`
+			Definition  struct {
+				Arguments json.RawMessage `json:"arguments"`
+				Options   json.RawMessage `json:"options"`
+			} `json:"definition"`
+		} `json:"commands"`
+	}
+	err := json.Unmarshal(output, &list)
+	if err != nil {
+		return nil, errors.Wraps(err, "Error decoding drush command list")
+	}
+
+	// Commands without arguments or options have an empty array instead of an object, which is ignored
+	descriptions := func(raw json.RawMessage) map[string]string {
+		var inputs map[string]input
+		json.Unmarshal(raw, &inputs)
+
+		described := map[string]string{}
+		for name, input := range inputs {
+			described[name] = input.Description
+		}
+		return described
+	}
+
+	commands := []DrushCommand{}
+	for _, command := range list.Commands {
+		commands = append(commands, DrushCommand{
+			Name:        command.Name,
+			Aliases:     command.Aliases,
+			Description: command.Description,
+			Arguments:   descriptions(command.Definition.Arguments),
+			Options:     descriptions(command.Definition.Options),
+		})
+	}
+	return commands, nil
+}
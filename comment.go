@@ -0,0 +1,79 @@
+package drupal
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/phayes/errors"
+)
+
+// GetCommentTypes gets the IDs of all comment types.
+// Returns ErrModuleNotEnabled if the comment module is not enabled.
+func (s Site) GetCommentTypes() ([]string, error) {
+	err := s.requireModule("comment")
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	err = s.phpEval("print json_encode(\\Drupal::configFactory()->listAll('comment.type.'));", &names)
+	if err != nil {
+		return nil, err
+	}
+
+	commentTypes := []string{}
+	for _, name := range names {
+		commentTypes = append(commentTypes, strings.TrimPrefix(name, "comment.type."))
+	}
+	return commentTypes, nil
+}
+
+// GetCommentSettings gets the full comment.type.* config object for a comment type.
+// Returns ErrModuleNotEnabled if the comment module is not enabled.
+func (s Site) GetCommentSettings(commentType string) (map[string]interface{}, error) {
+	err := s.requireModule("comment")
+	if err != nil {
+		return nil, err
+	}
+
+	var settings map[string]interface{}
+	err = s.getConfig("comment.type."+commentType, &settings)
+	if err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// SetCommentsEnabled sets whether comments are open or hidden by default on new entities of a bundle.
+// The status in the default value of every comment field on the bundle is updated.
+// The default_mode field setting is left alone, since it only controls whether comments are threaded or flat, not whether they are open.
+// Returns ErrModuleNotEnabled if the comment module is not enabled.
+func (s Site) SetCommentsEnabled(entityType, bundle string, enabled bool) error {
+	err := s.requireModule("comment")
+	if err != nil {
+		return err
+	}
+
+	// Comment status constants from CommentItemInterface
+	status := 0 // HIDDEN
+	if enabled {
+		status = 2 // OPEN
+	}
+
+	phpCode := "$count = 0; " +
+		"foreach (\\Drupal::service('entity_field.manager')->getFieldDefinitions(" + phpString(entityType) + ", " + phpString(bundle) + ") as $field) { " +
+		"if ($field->getType() != 'comment' || !($field instanceof \\Drupal\\field\\FieldConfigInterface)) { continue; } " +
+		"$value = $field->getDefaultValueLiteral(); $value[0]['status'] = " + strconv.Itoa(status) + "; $field->setDefaultValue($value)->save(); $count++; " +
+		"} " +
+		"print json_encode($count);"
+
+	var count int
+	err = s.phpEval(phpCode, &count)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return errors.Newf("No comment fields found on %v %v", entityType, bundle)
+	}
+	return nil
+}
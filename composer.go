@@ -0,0 +1,123 @@
+package drupal
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/phayes/errors"
+)
+
+// ComposerPackage is a composer package required by, or installed in, the site's composer project
+type ComposerPackage struct {
+	Name    string   `json:"name"`
+	Version string   `json:"version"` // For required packages this is the version constraint
+	Type    string   `json:"type"`
+	License []string `json:"license"`
+	Path    string   `json:"-"` // Absolute install path. Only set for installed packages
+}
+
+// composerRoot finds the directory containing the site's composer.json.
+// This is either the drupal root, or for the common "web" docroot layout, it's parent directory.
+// Returns an *os.PathError for which os.IsNotExist is true if there is no composer.json in either.
+func (s Site) composerRoot() (string, error) {
+	status, err := s.GetStatus()
+	if err != nil {
+		return "", err
+	}
+
+	for _, dir := range []string{status.Root, filepath.Dir(status.Root)} {
+		_, err := os.Stat(filepath.Join(dir, "composer.json"))
+		if err == nil {
+			return dir, nil
+		}
+	}
+	return "", &os.PathError{Op: "find composer.json", Path: status.Root, Err: os.ErrNotExist}
+}
+
+// GetComposerInfo gets the packages required in the site's composer.json, keyed by package name
+func (s Site) GetComposerInfo() (map[string]*ComposerPackage, error) {
+	root, err := s.composerRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(root, "composer.json"))
+	if err != nil {
+		return nil, errors.Wraps(err, "Error reading composer.json")
+	}
+
+	var composer struct {
+		Require map[string]string `json:"require"`
+	}
+	err = json.Unmarshal(data, &composer)
+	if err != nil {
+		return nil, errors.Wraps(err, "Error reading composer.json")
+	}
+
+	packages := map[string]*ComposerPackage{}
+	for name, version := range composer.Require {
+		packages[name] = &ComposerPackage{Name: name, Version: version}
+	}
+	return packages, nil
+}
+
+// GetInstalledComposerPackages gets the packages installed in the site's vendor directory, keyed by package name
+func (s Site) GetInstalledComposerPackages() (map[string]*ComposerPackage, error) {
+	root, err := s.composerRoot()
+	if err != nil {
+		return nil, err
+	}
+	return readComposerInstalled(filepath.Join(root, "vendor", "composer", "installed.json"))
+}
+
+// HasComposerPackage checks if a package is installed in the site's vendor directory
+func (s Site) HasComposerPackage(packageName string) (bool, error) {
+	packages, err := s.GetInstalledComposerPackages()
+	if err != nil {
+		return false, err
+	}
+	_, ok := packages[packageName]
+	return ok, nil
+}
+
+// readComposerInstalled reads a vendor/composer/installed.json file, as written by composer 1 or composer 2
+func readComposerInstalled(path string) (map[string]*ComposerPackage, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wraps(err, "Error reading installed composer packages")
+	}
+
+	type installedPackage struct {
+		ComposerPackage
+		InstallPath string `json:"install-path"`
+	}
+
+	// Composer 1 writes a list of packages, composer 2 wraps the list in an object
+	var installed []installedPackage
+	err = json.Unmarshal(data, &installed)
+	if err != nil {
+		var wrapped struct {
+			Packages []installedPackage `json:"packages"`
+		}
+		err = json.Unmarshal(data, &wrapped)
+		if err != nil {
+			return nil, errors.Wraps(err, "Error reading installed composer packages")
+		}
+		installed = wrapped.Packages
+	}
+
+	vendor := filepath.Dir(filepath.Dir(path))
+	packages := map[string]*ComposerPackage{}
+	for _, pkg := range installed {
+		composerPackage := pkg.ComposerPackage
+		if pkg.InstallPath != "" {
+			composerPackage.Path = filepath.Join(filepath.Dir(path), pkg.InstallPath)
+		} else {
+			composerPackage.Path = filepath.Join(vendor, pkg.Name)
+		}
+		packages[pkg.Name] = &composerPackage
+	}
+	return packages, nil
+}
@@ -0,0 +1,368 @@
+package drupal
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
+
+	"github.com/phayes/errors"
+)
+
+// getConfig reads a configuration object using "drush config-get" and decodes it into v
+func (s Site) getConfig(name string, v interface{}) error {
+	output, _, errs := s.Drush("config-get", name, "--format=json")
+	if errs != nil {
+		return errs
+	}
+
+	err := json.Unmarshal([]byte(output), v)
+	if err != nil {
+		return errors.Wrapf(err, "Error reading drupal config %v", name)
+	}
+	return nil
+}
+
+// configExists checks if a configuration object exists in the active config
+func (s Site) configExists(name string) (bool, error) {
+	var exists bool
+	err := s.phpEval("print json_encode(!\\Drupal::config("+phpString(name)+")->isNew());", &exists)
+	if err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// setConfig sets a single key in a configuration object.
+// Nested keys are separated by a period (eg "cache.page.max_age").
+func (s Site) setConfig(name string, key string, value interface{}) error {
+	phpVal, err := phpValue(value)
+	if err != nil {
+		return errors.Wrapf(err, "Error writing drupal config %v", name)
+	}
+
+	phpCode := "\\Drupal::configFactory()->getEditable(" + phpString(name) + ")->set(" + phpString(key) + ", " + phpVal + ")->save();"
+	return s.phpEval(phpCode, nil)
+}
+
+// PerformanceSettings contains caching and aggregation settings from the system.performance config
+type PerformanceSettings struct {
+	CacheLifetime       int  // Minimum cache lifetime in seconds. Drupal 8 no longer has this setting, so it is usually 0
+	PageCacheMaximumAge int  // Maximum age of pages served from the page cache, in seconds
+	CSSPreprocess       bool // Whether CSS files are aggregated
+	JSPreprocess        bool // Whether JavaScript files are aggregated
+	GZIPCompression     bool // Whether responses are gzip compressed
+}
+
+// GetPerformanceSettings gets the caching and aggregation settings from the system.performance config
+func (s Site) GetPerformanceSettings() (*PerformanceSettings, error) {
+	var config struct {
+		Cache struct {
+			Lifetime int `json:"lifetime"`
+			Page     struct {
+				MaxAge int `json:"max_age"`
+			} `json:"page"`
+		} `json:"cache"`
+		CSS struct {
+			Preprocess bool `json:"preprocess"`
+		} `json:"css"`
+		JS struct {
+			Preprocess bool `json:"preprocess"`
+		} `json:"js"`
+		Response struct {
+			GZIP bool `json:"gzip"`
+		} `json:"response"`
+	}
+
+	err := s.getConfig("system.performance", &config)
+	if err != nil {
+		return nil, err
+	}
+
+	performance := &PerformanceSettings{
+		CacheLifetime:       config.Cache.Lifetime,
+		PageCacheMaximumAge: config.Cache.Page.MaxAge,
+		CSSPreprocess:       config.CSS.Preprocess,
+		JSPreprocess:        config.JS.Preprocess,
+		GZIPCompression:     config.Response.GZIP,
+	}
+	return performance, nil
+}
+
+// SetPageCacheMaxAge sets the maximum age, in seconds, of pages served from the page cache
+func (s Site) SetPageCacheMaxAge(seconds int) error {
+	return s.setConfig("system.performance", "cache.page.max_age", seconds)
+}
+
+// Error levels for displaying errors on the site, as set in the system.logging config
+const (
+	ErrorLevelHide    = iota // Don't display any errors
+	ErrorLevelSome           // Display errors and warnings
+	ErrorLevelAll            // Display all messages
+	ErrorLevelVerbose        // Display all messages, with backtrace information
+)
+
+// errorLevels maps ErrorLevel constants to their values in the system.logging config
+var errorLevels = []string{"hide", "some", "all", "verbose"}
+
+// LoggingSettings contains the error display and syslog settings for a site
+type LoggingSettings struct {
+	ErrorLevel     int    // One of the ErrorLevel constants
+	SyslogIdentity string // Identity string prepended to syslog messages
+	SyslogFacility int    // Syslog facility (eg 128 for LOG_LOCAL0)
+	SyslogEnabled  bool   // Whether the syslog module is enabled
+}
+
+// GetLoggingSettings gets the error level from the system.logging config and, if the syslog module is enabled, the syslog.settings config
+func (s Site) GetLoggingSettings() (*LoggingSettings, error) {
+	var logging struct {
+		ErrorLevel string `json:"error_level"`
+	}
+	err := s.getConfig("system.logging", &logging)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := &LoggingSettings{ErrorLevel: ErrorLevelHide}
+	for level, name := range errorLevels {
+		if name == logging.ErrorLevel {
+			settings.ErrorLevel = level
+		}
+	}
+
+	settings.SyslogEnabled, err = s.moduleEnabled("syslog")
+	if err != nil {
+		return nil, err
+	}
+	if !settings.SyslogEnabled {
+		return settings, nil
+	}
+
+	var syslog struct {
+		Identity string `json:"identity"`
+		Facility int    `json:"facility"`
+	}
+	err = s.getConfig("syslog.settings", &syslog)
+	if err != nil {
+		return nil, err
+	}
+	settings.SyslogIdentity = syslog.Identity
+	settings.SyslogFacility = syslog.Facility
+
+	return settings, nil
+}
+
+// SetLoggingLevel sets the error level for displaying errors on the site.
+// level should be one of the ErrorLevel constants.
+func (s Site) SetLoggingLevel(level int) error {
+	if level < 0 || level >= len(errorLevels) {
+		return errors.Newf("Invalid error level %v", level)
+	}
+	return s.setConfig("system.logging", "error_level", errorLevels[level])
+}
+
+// GetSiteUUID gets the site UUID from the system.site config
+func (s Site) GetSiteUUID() (string, error) {
+	var site struct {
+		UUID string `json:"uuid"`
+	}
+	err := s.getConfig("system.site", &site)
+	if err != nil {
+		return "", err
+	}
+	return site.UUID, nil
+}
+
+// ValidateSiteUUID checks that the site UUID matches the expected UUID, returning ErrSiteUUIDMismatch if it does not.
+// Configuration can only be imported into a site with the same UUID as the site it was exported from.
+func (s Site) ValidateSiteUUID(expected string) error {
+	uuid, err := s.GetSiteUUID()
+	if err != nil {
+		return err
+	}
+	if uuid != expected {
+		return errors.Wrapf(ErrSiteUUIDMismatch, "Expected site UUID %v, got %v", expected, uuid)
+	}
+	return nil
+}
+
+// phpListConfig returns php code that loads the raw data of all config objects with the given name prefix into $configs, keyed by config name
+func phpListConfig(prefix string) string {
+	return "$configs = array(); foreach (\\Drupal::configFactory()->loadMultiple(\\Drupal::configFactory()->listAll(" + phpString(prefix) + ")) as $name => $config) { $configs[$name] = $config->getRawData(); } "
+}
+
+// GetConfigTranslation gets the translated values of a config object in a language.
+// Only translated keys are included.
+// Returns ErrModuleNotEnabled if the language module is not enabled.
+func (s Site) GetConfigTranslation(configName, langCode string) (map[string]interface{}, error) {
+	err := s.requireModule("language")
+	if err != nil {
+		return nil, err
+	}
+
+	var translation map[string]interface{}
+	err = s.phpEval("print json_encode((object) \\Drupal::languageManager()->getLanguageConfigOverride("+phpString(langCode)+", "+phpString(configName)+")->get());", &translation)
+	if err != nil {
+		return nil, err
+	}
+	return translation, nil
+}
+
+// SetConfigTranslation replaces the translated values of a config object in a language.
+// Returns ErrModuleNotEnabled if the language module is not enabled.
+func (s Site) SetConfigTranslation(configName, langCode string, data map[string]interface{}) error {
+	err := s.requireModule("language")
+	if err != nil {
+		return err
+	}
+
+	phpData, err := phpValue(data)
+	if err != nil {
+		return err
+	}
+	return s.phpEval("\\Drupal::languageManager()->getLanguageConfigOverride("+phpString(langCode)+", "+phpString(configName)+")->setData("+phpData+")->save();", nil)
+}
+
+// GetAvailableConfigTranslations gets the codes of the languages a config object has been translated into.
+// Returns ErrModuleNotEnabled if the language module is not enabled.
+func (s Site) GetAvailableConfigTranslations(configName string) ([]string, error) {
+	err := s.requireModule("language")
+	if err != nil {
+		return nil, err
+	}
+
+	phpCode := "$languageManager = \\Drupal::languageManager(); " +
+		"$langCodes = array(); " +
+		"foreach (array_keys($languageManager->getLanguages()) as $langCode) { if (!$languageManager->getLanguageConfigOverride($langCode, " + phpString(configName) + ")->isNew()) { $langCodes[] = $langCode; } } " +
+		"print json_encode($langCodes);"
+
+	var langCodes []string
+	err = s.phpEval(phpCode, &langCodes)
+	if err != nil {
+		return nil, err
+	}
+	return langCodes, nil
+}
+
+// GetConfigOverrideSource finds what is overriding a key in a config object, returning "settings" for overrides in settings.php,
+// "language" for config translations, or "module" for overrides provided by a module. An empty string means the key is not overridden.
+// Nested keys are separated by a period (eg "page.front").
+func (s Site) GetConfigOverrideSource(configName, key string) (string, error) {
+	phpCode := "$name = " + phpString(configName) + "; $parents = explode('.', " + phpString(key) + "); " +
+		"if (isset($GLOBALS['config'][$name]) && \\Drupal\\Component\\Utility\\NestedArray::keyExists($GLOBALS['config'][$name], $parents)) { print json_encode('settings'); return; } " +
+		"$property = new \\ReflectionProperty(\\Drupal::configFactory(), 'configFactoryOverrides'); $property->setAccessible(TRUE); " +
+		"foreach ($property->getValue(\\Drupal::configFactory()) as $override) { " +
+		"$overrides = $override->loadOverrides(array($name)); " +
+		"if (isset($overrides[$name]) && \\Drupal\\Component\\Utility\\NestedArray::keyExists($overrides[$name], $parents)) { " +
+		"print json_encode($override instanceof \\Drupal\\language\\Config\\LanguageConfigFactoryOverrideInterface ? 'language' : 'module'); return; " +
+		"} " +
+		"} " +
+		"print json_encode('');"
+
+	var source string
+	err := s.phpEval(phpCode, &source)
+	if err != nil {
+		return "", err
+	}
+	return source, nil
+}
+
+// GetActiveConfigValue gets the effective value of a key in a config object, including any overrides.
+// Nested keys are separated by a period (eg "page.front").
+func (s Site) GetActiveConfigValue(configName, key string) (interface{}, error) {
+	var value interface{}
+	err := s.phpEval("print json_encode(\\Drupal::config("+phpString(configName)+")->get("+phpString(key)+"));", &value)
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// GetModuleConfiguration gets the names of the config objects owned by a module, which are those prefixed with the module's name (eg "system.site" for system)
+func (s Site) GetModuleConfiguration(moduleName string) ([]string, error) {
+	var names []string
+	err := s.phpEval("print json_encode(\\Drupal::configFactory()->listAll("+phpString(moduleName+".")+"));", &names)
+	if err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// GetModuleConfigurationData gets the data of every config object owned by a module, keyed by config name
+func (s Site) GetModuleConfigurationData(moduleName string) (map[string]map[string]interface{}, error) {
+	phpCode := phpListConfig(moduleName+".") + "print json_encode((object) array_map(function ($data) { return (object) $data; }, $configs));"
+
+	var configs map[string]map[string]interface{}
+	err := s.phpEval(phpCode, &configs)
+	if err != nil {
+		return nil, err
+	}
+	return configs, nil
+}
+
+// GetConfigNormalized gets a configuration object with the keys that vary between environments removed,
+// so it can be compared with the same configuration from another site.
+// The top level uuid and _core keys are removed, as is langcode if it is the site's default language.
+func (s Site) GetConfigNormalized(configName string) (map[string]interface{}, error) {
+	var config map[string]interface{}
+	err := s.getConfig(configName, &config)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultLangcode, err := s.getDefaultLangcode()
+	if err != nil {
+		return nil, err
+	}
+
+	return normalizeConfig(config, defaultLangcode), nil
+}
+
+// CompareConfigToFile checks if a configuration object matches an exported YAML configuration file once both are normalized as with GetConfigNormalized
+func (s Site) CompareConfigToFile(configName, filePath string) (bool, error) {
+	config, err := s.GetConfigNormalized(configName)
+	if err != nil {
+		return false, err
+	}
+
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return false, errors.Wrapf(err, "Error reading config file %v", filePath)
+	}
+
+	var fileConfig map[string]interface{}
+	err = s.phpEval("print json_encode((object) \\Drupal\\Component\\Serialization\\Yaml::decode("+phpString(string(content))+"));", &fileConfig)
+	if err != nil {
+		return false, err
+	}
+
+	defaultLangcode, err := s.getDefaultLangcode()
+	if err != nil {
+		return false, err
+	}
+
+	return reflect.DeepEqual(config, normalizeConfig(fileConfig, defaultLangcode)), nil
+}
+
+// getDefaultLangcode gets the site's default language from the system.site config
+func (s Site) getDefaultLangcode() (string, error) {
+	var site struct {
+		DefaultLangcode string `json:"default_langcode"`
+	}
+	err := s.getConfig("system.site", &site)
+	if err != nil {
+		return "", err
+	}
+	return site.DefaultLangcode, nil
+}
+
+// normalizeConfig returns a copy of config without the uuid and _core keys, or langcode if it is defaultLangcode
+func normalizeConfig(config map[string]interface{}, defaultLangcode string) map[string]interface{} {
+	normalized := map[string]interface{}{}
+	for key, value := range config {
+		if key == "uuid" || key == "_core" || (key == "langcode" && value == defaultLangcode) {
+			continue
+		}
+		normalized[key] = value
+	}
+	return normalized
+}
@@ -0,0 +1,50 @@
+package drupal
+
+import (
+	"sort"
+
+	"github.com/phayes/errors"
+)
+
+// phpLoadConfigEntityStorage returns php code that loads the storage of a config entity type into $storage, printing null and returning if it is not a config entity type
+func phpLoadConfigEntityStorage(entityType string) string {
+	return "$definition = \\Drupal::entityTypeManager()->getDefinition(" + phpString(entityType) + ", FALSE); " +
+		"if (!($definition instanceof \\Drupal\\Core\\Config\\Entity\\ConfigEntityTypeInterface)) { print json_encode(NULL); return; } " +
+		"$storage = \\Drupal::entityTypeManager()->getStorage(" + phpString(entityType) + "); "
+}
+
+// GetConfigEntityList gets the IDs of all config entities of a type (eg "view", "user_role" or "image_style"), sorted by ID
+func (s Site) GetConfigEntityList(entityType string) ([]string, error) {
+	var ids *[]string
+	err := s.phpEval(phpLoadConfigEntityStorage(entityType)+"print json_encode(array_map('strval', array_keys($storage->loadMultiple())));", &ids)
+	if err != nil {
+		return nil, err
+	}
+	if ids == nil {
+		return nil, errors.Newf("%v is not a config entity type", entityType)
+	}
+
+	sort.Strings(*ids)
+	return *ids, nil
+}
+
+// GetConfigEntityData loads a config entity and gets it's exported properties, as they would be written to config
+func (s Site) GetConfigEntityData(entityType, id string) (map[string]interface{}, error) {
+	phpCode := phpLoadConfigEntityStorage(entityType) +
+		"$entity = $storage->load(" + phpString(id) + "); " +
+		"print json_encode($entity ? (object) $entity->toArray() : FALSE);"
+
+	var data interface{}
+	err := s.phpEval(phpCode, &data)
+	if err != nil {
+		return nil, err
+	}
+	switch data := data.(type) {
+	case nil:
+		return nil, errors.Newf("%v is not a config entity type", entityType)
+	case map[string]interface{}:
+		return data, nil
+	default:
+		return nil, errors.Wrapf(ErrEntityNotFound, "Could not load %v %v", entityType, id)
+	}
+}
@@ -0,0 +1,94 @@
+package drupal
+
+import (
+	"github.com/phayes/errors"
+)
+
+// ConfigSplit is a config_split configuration split
+type ConfigSplit struct {
+	ID        string   `json:"id"`
+	Label     string   `json:"label"`
+	Folder    string   `json:"folder"`
+	Status    bool     `json:"status"`
+	GrayList  []string `json:"graylist"`  // Config that is only split off if it differs from the main config
+	BlackList []string `json:"blacklist"` // Config that is always split off
+}
+
+// phpConfigSplit is php code defining a $configSplit closure that formats config_split.config_split.* config data as a ConfigSplit.
+// config_split 2.x renamed the graylist to partial_list and the blacklist to complete_list.
+const phpConfigSplit = "$configSplit = function ($data) { " +
+	"$list = function ($old, $new) use ($data) { return array_values((array) (isset($data[$new]) ? $data[$new] : (isset($data[$old]) ? $data[$old] : array()))); }; " +
+	"return array('id' => $data['id'], 'label' => $data['label'], 'folder' => (string) $data['folder'], 'status' => (bool) $data['status'], 'graylist' => $list('graylist', 'partial_list'), 'blacklist' => $list('blacklist', 'complete_list')); " +
+	"}; "
+
+// GetConfigSplits gets all configuration splits. Status reflects any overrides in settings.php.
+// Returns ErrModuleNotEnabled if the config_split module is not enabled.
+func (s Site) GetConfigSplits() ([]ConfigSplit, error) {
+	err := s.requireModule("config_split")
+	if err != nil {
+		return nil, err
+	}
+
+	phpCode := phpConfigSplit +
+		"$splits = array(); " +
+		"foreach (\\Drupal::configFactory()->loadMultiple(\\Drupal::configFactory()->listAll('config_split.config_split.')) as $config) { " +
+		"$data = $config->getRawData(); $data['status'] = $config->get('status'); $splits[] = $configSplit($data); " +
+		"} " +
+		"print json_encode($splits);"
+
+	var splits []ConfigSplit
+	err = s.phpEval(phpCode, &splits)
+	if err != nil {
+		return nil, err
+	}
+	return splits, nil
+}
+
+// EnableConfigSplit activates a configuration split. An override of it's status in settings.php still takes precedence.
+// Returns ErrModuleNotEnabled if the config_split module is not enabled.
+func (s Site) EnableConfigSplit(id string) error {
+	return s.setConfigSplitStatus(id, true)
+}
+
+// DisableConfigSplit deactivates a configuration split. An override of it's status in settings.php still takes precedence.
+// Returns ErrModuleNotEnabled if the config_split module is not enabled.
+func (s Site) DisableConfigSplit(id string) error {
+	return s.setConfigSplitStatus(id, false)
+}
+
+func (s Site) setConfigSplitStatus(id string, status bool) error {
+	err := s.requireConfigSplit(id)
+	if err != nil {
+		return err
+	}
+	return s.setConfig("config_split.config_split."+id, "status", status)
+}
+
+// ImportConfigWithSplit imports the config of a single configuration split using "drush config-split-import".
+// Returns ErrModuleNotEnabled if the config_split module is not enabled.
+func (s Site) ImportConfigWithSplit(id string) error {
+	err := s.requireConfigSplit(id)
+	if err != nil {
+		return err
+	}
+
+	_, _, errs := s.Drush("config-split-import", id)
+	return errs
+}
+
+// requireConfigSplit returns an error if the config_split module is not enabled or the split does not exist
+func (s Site) requireConfigSplit(id string) error {
+	err := s.requireModule("config_split")
+	if err != nil {
+		return err
+	}
+
+	exists, err := s.configExists("config_split.config_split." + id)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.Newf("Config split %v not found", id)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package drupal
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/phayes/errors"
+)
+
+// ConfigDiffEntry describes how a config object in the sync directory differs from the active config
+type ConfigDiffEntry struct {
+	Name        string
+	Status      string   // "added" if the config only exists in the sync directory, "deleted" if it only exists in the active config, otherwise "updated"
+	AddedKeys   []string // Keys in the sync config that are not in the active config. Nested keys are separated by a period
+	RemovedKeys []string // Keys in the active config that are not in the sync config
+	ChangedKeys []string // Keys with different values in the sync and active config
+}
+
+// GetConfigSyncStatus compares the active config with the config in the sync directory, describing the changes that a config import would make
+func (s Site) GetConfigSyncStatus() ([]ConfigDiffEntry, error) {
+	phpCode := "$active = \\Drupal::service('config.storage'); " +
+		"$sync = \\Drupal::hasService('config.storage.sync') ? \\Drupal::service('config.storage.sync') : new \\Drupal\\Core\\Config\\FileStorage(config_get_config_directory(CONFIG_SYNC_DIRECTORY)); " +
+		"$comparer = new \\Drupal\\Core\\Config\\StorageComparer($sync, $active, \\Drupal::service('config.manager')); " +
+		"$comparer->createChangelist(); " +
+		"$changes = array(); " +
+		"foreach (array('create' => 'added', 'update' => 'updated', 'delete' => 'deleted') as $op => $status) { foreach ($comparer->getChangelist($op) as $name) { " +
+		"$changes[] = array('name' => $name, 'status' => $status, 'active' => (object) ($active->read($name) ?: array()), 'sync' => (object) ($sync->read($name) ?: array())); " +
+		"} } " +
+		"print json_encode($changes);"
+
+	var changes []struct {
+		Name   string                 `json:"name"`
+		Status string                 `json:"status"`
+		Active map[string]interface{} `json:"active"`
+		Sync   map[string]interface{} `json:"sync"`
+	}
+	err := s.phpEval(phpCode, &changes)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []ConfigDiffEntry{}
+	for _, change := range changes {
+		entry := diffConfig(change.Active, change.Sync)
+		entry.Name = change.Name
+		entry.Status = change.Status
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// diffConfig compares the keys of two config objects, returning a ConfigDiffEntry with the added, removed and changed keys set
+func diffConfig(active, sync map[string]interface{}) ConfigDiffEntry {
+	activeKeys := map[string]interface{}{}
+	flattenConfig("", active, activeKeys)
+	syncKeys := map[string]interface{}{}
+	flattenConfig("", sync, syncKeys)
+
+	entry := ConfigDiffEntry{AddedKeys: []string{}, RemovedKeys: []string{}, ChangedKeys: []string{}}
+	for key, syncVal := range syncKeys {
+		activeVal, ok := activeKeys[key]
+		if !ok {
+			entry.AddedKeys = append(entry.AddedKeys, key)
+		} else if !reflect.DeepEqual(activeVal, syncVal) {
+			entry.ChangedKeys = append(entry.ChangedKeys, key)
+		}
+	}
+	for key := range activeKeys {
+		if _, ok := syncKeys[key]; !ok {
+			entry.RemovedKeys = append(entry.RemovedKeys, key)
+		}
+	}
+
+	sort.Strings(entry.AddedKeys)
+	sort.Strings(entry.RemovedKeys)
+	sort.Strings(entry.ChangedKeys)
+	return entry
+}
+
+// flattenConfig adds the leaf values of a nested config object to flat, keyed by their period separated path
+func flattenConfig(prefix string, config map[string]interface{}, flat map[string]interface{}) {
+	for key, val := range config {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		nested, ok := val.(map[string]interface{})
+		if ok && len(nested) > 0 {
+			flattenConfig(key, nested, flat)
+		} else {
+			flat[key] = val
+		}
+	}
+}
+
+// GetConfigExportDirectory gets the absolute filesystem path of the config sync directory reported by "drush status".
+// Paths relative to the drupal root, such as "../config/sync", and local stream wrapper URIs, such as "private://config/sync", are resolved.
+// Returns ErrConfigSyncNotSet if no sync directory is configured, or an *os.PathError for which os.IsNotExist is true if the directory does not exist.
+func (s Site) GetConfigExportDirectory() (string, error) {
+	status, err := s.GetStatus()
+	if err != nil {
+		return "", err
+	}
+	if status.ConfigSync == "" {
+		return "", errors.Wraps(ErrConfigSyncNotSet, "Config sync directory is empty")
+	}
+
+	var directory string
+	switch {
+	case strings.Contains(status.ConfigSync, "://"):
+		err = s.phpEval("print json_encode((string) \\Drupal::service('file_system')->realpath("+phpString(status.ConfigSync)+"));", &directory)
+		if err != nil {
+			return "", err
+		}
+		if directory == "" {
+			return "", &os.PathError{Op: "find config sync directory", Path: status.ConfigSync, Err: os.ErrNotExist}
+		}
+	case filepath.IsAbs(status.ConfigSync):
+		directory = filepath.Clean(status.ConfigSync)
+	default:
+		directory = filepath.Join(status.Root, status.ConfigSync)
+	}
+
+	info, err := os.Stat(directory)
+	if os.IsNotExist(err) {
+		return "", err
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "Error reading config sync directory %v", directory)
+	}
+	if !info.IsDir() {
+		return "", errors.Newf("Config sync directory %v is not a directory", directory)
+	}
+	return directory, nil
+}
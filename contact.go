@@ -0,0 +1,64 @@
+package drupal
+
+import (
+	"github.com/phayes/errors"
+)
+
+// ContactForm is a contact form, as defined in a contact.form.* config object
+type ContactForm struct {
+	ID         string   `json:"id"`
+	Label      string   `json:"label"`
+	Recipients []string `json:"recipients"`
+	Message    string   `json:"message"` // Message displayed after the form is submitted
+	Subject    string   `json:"subject"` // Core contact forms do not set a subject, so this is only set by modules that add one
+}
+
+// phpContactForm is php code defining a $contactForm closure that formats contact.form.* config data as a ContactForm
+const phpContactForm = "$contactForm = function ($data) { return array('id' => $data['id'], 'label' => $data['label'], 'recipients' => array_values((array) $data['recipients']), 'message' => isset($data['message']) ? (string) $data['message'] : '', 'subject' => isset($data['subject']) ? (string) $data['subject'] : ''); }; "
+
+// GetContactForms gets all contact forms.
+// Returns ErrModuleNotEnabled if the contact module is not enabled.
+func (s Site) GetContactForms() ([]ContactForm, error) {
+	err := s.requireModule("contact")
+	if err != nil {
+		return nil, err
+	}
+
+	var forms []ContactForm
+	err = s.phpEval(phpContactForm+phpListConfig("contact.form.")+"print json_encode(array_values(array_map($contactForm, $configs)));", &forms)
+	if err != nil {
+		return nil, err
+	}
+	return forms, nil
+}
+
+// GetContactForm gets a single contact form.
+// Returns ErrModuleNotEnabled if the contact module is not enabled.
+func (s Site) GetContactForm(id string) (*ContactForm, error) {
+	err := s.requireModule("contact")
+	if err != nil {
+		return nil, err
+	}
+
+	phpCode := phpContactForm + "$config = \\Drupal::config(" + phpString("contact.form."+id) + "); print json_encode($config->isNew() ? NULL : $contactForm($config->getRawData()));"
+
+	var form *ContactForm
+	err = s.phpEval(phpCode, &form)
+	if err != nil {
+		return nil, err
+	}
+	if form == nil {
+		return nil, errors.Newf("Contact form %v not found", id)
+	}
+	return form, nil
+}
+
+// SetContactFormRecipients sets the email addresses that receive a contact form's submissions.
+// Returns ErrModuleNotEnabled if the contact module is not enabled.
+func (s Site) SetContactFormRecipients(id string, emails []string) error {
+	_, err := s.GetContactForm(id)
+	if err != nil {
+		return err
+	}
+	return s.setConfig("contact.form."+id, "recipients", emails)
+}
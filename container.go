@@ -0,0 +1,39 @@
+package drupal
+
+import (
+	"github.com/phayes/errors"
+)
+
+// GetContainerParameter gets a parameter from drupal's service container (eg "session.storage.options")
+func (s Site) GetContainerParameter(parameter string) (interface{}, error) {
+	phpCode := "$container = \\Drupal::getContainer(); " +
+		"print json_encode($container->hasParameter(" + phpString(parameter) + ") ? array('value' => $container->getParameter(" + phpString(parameter) + ")) : NULL);"
+
+	var result *struct {
+		Value interface{} `json:"value"`
+	}
+	err := s.phpEval(phpCode, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, errors.Newf("Container parameter %v not found", parameter)
+	}
+	return result.Value, nil
+}
+
+// GetKernelParameters gets all parameters from drupal's service container, keyed by parameter name
+func (s Site) GetKernelParameters() (map[string]interface{}, error) {
+	// The compiled container does not expose it's parameters, so they are read from the protected property
+	phpCode := "$container = \\Drupal::getContainer(); " +
+		"if (method_exists($container, 'getParameterBag')) { $parameters = $container->getParameterBag()->all(); } " +
+		"else { $property = new \\ReflectionProperty($container, 'parameters'); $property->setAccessible(TRUE); $parameters = $property->getValue($container); } " +
+		"print json_encode((object) $parameters);"
+
+	var parameters map[string]interface{}
+	err := s.phpEval(phpCode, &parameters)
+	if err != nil {
+		return nil, err
+	}
+	return parameters, nil
+}
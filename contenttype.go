@@ -0,0 +1,72 @@
+package drupal
+
+import (
+	"github.com/phayes/errors"
+)
+
+// FieldConfig is the configuration of a field attached to a bundle, as defined in a field.field.* config object
+type FieldConfig struct {
+	FieldName   string                 `json:"field_name"`
+	Label       string                 `json:"label"`
+	FieldType   string                 `json:"field_type"`
+	Description string                 `json:"description"`
+	Required    bool                   `json:"required"`
+	Settings    map[string]interface{} `json:"settings"`
+}
+
+// ContentType is a node type, as defined in a node.type.* config object
+type ContentType struct {
+	Type            string                 `json:"type"`
+	Name            string                 `json:"name"`
+	Description     string                 `json:"description"`
+	Fields          []FieldConfig          `json:"fields"`
+	DisplaySettings map[string]interface{} `json:"display_settings"` // Components of the default view display, keyed by field name
+}
+
+// phpContentType is php code defining a $contentType closure that formats node.type.* config data as a ContentType
+const phpContentType = "$contentType = function ($data) { " +
+	"$fields = array(); " +
+	"foreach (\\Drupal::configFactory()->loadMultiple(\\Drupal::configFactory()->listAll('field.field.node.' . $data['type'] . '.')) as $config) { " +
+	"$field = $config->getRawData(); " +
+	"$fields[] = array('field_name' => $field['field_name'], 'label' => $field['label'], 'field_type' => $field['field_type'], 'description' => (string) $field['description'], 'required' => (bool) $field['required'], 'settings' => (object) $field['settings']); " +
+	"} " +
+	"$display = \\Drupal::config('core.entity_view_display.node.' . $data['type'] . '.default')->get('content'); " +
+	"return array('type' => $data['type'], 'name' => $data['name'], 'description' => (string) $data['description'], 'fields' => $fields, 'display_settings' => (object) $display); " +
+	"}; "
+
+// GetContentTypes gets all content types, including their fields
+func (s Site) GetContentTypes() ([]ContentType, error) {
+	phpCode := phpContentType + phpListConfig("node.type.") + "print json_encode(array_values(array_map($contentType, $configs)));"
+
+	var contentTypes []ContentType
+	err := s.phpEval(phpCode, &contentTypes)
+	if err != nil {
+		return nil, err
+	}
+	return contentTypes, nil
+}
+
+// GetContentType gets a single content type, including it's fields
+func (s Site) GetContentType(typeName string) (*ContentType, error) {
+	phpCode := phpContentType + "$config = \\Drupal::config(" + phpString("node.type."+typeName) + "); print json_encode($config->isNew() ? NULL : $contentType($config->getRawData()));"
+
+	var contentType *ContentType
+	err := s.phpEval(phpCode, &contentType)
+	if err != nil {
+		return nil, err
+	}
+	if contentType == nil {
+		return nil, errors.Newf("Content type %v not found", typeName)
+	}
+	return contentType, nil
+}
+
+// CreateContentType creates a new content type with the given Type, Name and Description.
+// Fields and DisplaySettings are not created, and should be added separately.
+func (s Site) CreateContentType(ct ContentType) error {
+	values, err := phpValue(map[string]string{"type": ct.Type, "name": ct.Name, "description": ct.Description})
+	if err != nil {
+		return err
+	}
+	return s.phpEval("\\Drupal::entityTypeManager()->getStorage('node_type')->create("+values+")->save();", nil)
+}
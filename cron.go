@@ -0,0 +1,49 @@
+package drupal
+
+import (
+	"time"
+
+	"github.com/phayes/errors"
+)
+
+// CronDisabled is the automated cron interval when automated cron is disabled
+const CronDisabled time.Duration = 0
+
+// GetCronInterval gets the interval at which automated cron runs, from the automated_cron.settings config.
+// Returns CronDisabled if automated cron is disabled, or ErrModuleNotEnabled if the automated_cron module is not enabled.
+func (s Site) GetCronInterval() (time.Duration, error) {
+	err := s.requireModule("automated_cron")
+	if err != nil {
+		return CronDisabled, err
+	}
+
+	var settings struct {
+		Interval int `json:"interval"`
+	}
+	err = s.getConfig("automated_cron.settings", &settings)
+	if err != nil {
+		return CronDisabled, err
+	}
+	if settings.Interval <= 0 {
+		return CronDisabled, nil
+	}
+	return time.Duration(settings.Interval) * time.Second, nil
+}
+
+// SetCronInterval sets the interval at which automated cron runs. The interval is rounded down to whole seconds.
+// Use CronDisabled to disable automated cron.
+// Returns an error for negative intervals and for intervals shorter than one second, which would otherwise disable cron.
+// Returns ErrModuleNotEnabled if the automated_cron module is not enabled.
+func (s Site) SetCronInterval(interval time.Duration) error {
+	if interval < 0 {
+		return errors.Newf("Invalid cron interval %v. Interval cannot be negative", interval)
+	}
+	if interval != CronDisabled && interval < time.Second {
+		return errors.Newf("Invalid cron interval %v. Interval must be at least one second", interval)
+	}
+	err := s.requireModule("automated_cron")
+	if err != nil {
+		return err
+	}
+	return s.setConfig("automated_cron.settings", "interval", int(interval/time.Second))
+}
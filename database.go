@@ -0,0 +1,132 @@
+package drupal
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/phayes/errors"
+)
+
+// GetDatabaseCharset gets the character set of the site's MySQL database
+func (s Site) GetDatabaseCharset() (string, error) {
+	return s.getSQLVariable("character_set_database")
+}
+
+// GetDatabaseCollation gets the collation of the site's MySQL database
+func (s Site) GetDatabaseCollation() (string, error) {
+	return s.getSQLVariable("collation_database")
+}
+
+// ValidateDatabaseCharset returns an error if the character set of the site's MySQL database is not expected (eg "utf8mb4")
+func (s Site) ValidateDatabaseCharset(expected string) error {
+	charset, err := s.GetDatabaseCharset()
+	if err != nil {
+		return err
+	}
+	if charset != expected {
+		return errors.Newf("Expected database character set %v, got %v", expected, charset)
+	}
+	return nil
+}
+
+// getSQLVariable gets a MySQL system variable using "drush sql-query"
+func (s Site) getSQLVariable(name string) (string, error) {
+	output, _, errs := s.Drush("sql-query", "SHOW VARIABLES LIKE '"+name+"';")
+	if errs != nil {
+		return "", errs
+	}
+
+	value, ok := parseSQLVariable(output, name)
+	if !ok {
+		return "", errors.Newf("Database variable %v not found", name)
+	}
+	return value, nil
+}
+
+// parseSQLVariable finds the value of a variable in the tab separated output of SHOW VARIABLES
+func parseSQLVariable(output string, name string) (string, bool) {
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.SplitN(strings.TrimSpace(line), "\t", 2)
+		if len(fields) == 2 && fields[0] == name {
+			return strings.TrimSpace(fields[1]), true
+		}
+	}
+	return "", false
+}
+
+// GetTableCounts gets the exact number of rows in each of some database tables, keyed by table name
+func (s Site) GetTableCounts(tables []string) (map[string]int64, error) {
+	counts := map[string]int64{}
+	for _, table := range tables {
+		if !validTableName(table) {
+			return nil, errors.Newf("Invalid table name %v", table)
+		}
+		output, _, errs := s.Drush("sql-query", "SELECT COUNT(*) FROM "+table+";")
+		if errs != nil {
+			return nil, errs
+		}
+
+		lines := strings.Split(strings.TrimSpace(output), "\n")
+		count, err := strconv.ParseInt(strings.TrimSpace(lines[len(lines)-1]), 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error counting rows in %v", table)
+		}
+		counts[table] = count
+	}
+	return counts, nil
+}
+
+// GetAllTableCounts gets the number of rows in every table in the site's MySQL database, keyed by table name.
+// The counts come from information_schema.tables, so are only estimates for InnoDB tables.
+func (s Site) GetAllTableCounts() (map[string]int64, error) {
+	output, _, errs := s.Drush("sql-query", "SELECT table_name, table_rows FROM information_schema.tables WHERE table_schema = DATABASE();")
+	if errs != nil {
+		return nil, errs
+	}
+
+	counts := map[string]int64{}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(strings.TrimSpace(line), "\t")
+		if len(fields) != 2 {
+			continue
+		}
+		count, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			continue // Header, or a view with a NULL row count
+		}
+		counts[fields[0]] = count
+	}
+	return counts, nil
+}
+
+// GetTableSize gets the size of a table in the site's MySQL database, including it's indexes, in bytes
+func (s Site) GetTableSize(tableName string) (int64, error) {
+	if !validTableName(tableName) {
+		return 0, errors.Newf("Invalid table name %v", tableName)
+	}
+	output, _, errs := s.Drush("sql-query", "SELECT data_length + index_length FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = '"+tableName+"';")
+	if errs != nil {
+		return 0, errs
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		size, err := strconv.ParseInt(strings.TrimSpace(line), 10, 64)
+		if err == nil {
+			return size, nil
+		}
+	}
+	return 0, errors.Newf("Table %v not found", tableName)
+}
+
+// validTableName checks if a table name is safe to use unquoted in SQL
+func validTableName(table string) bool {
+	if table == "" {
+		return false
+	}
+	for _, c := range table {
+		if !(c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')) {
+			return false
+		}
+	}
+	return true
+}
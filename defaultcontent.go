@@ -0,0 +1,55 @@
+package drupal
+
+import (
+	"github.com/phayes/errors"
+)
+
+// DefaultContent is a content entity exported by the default_content module, to be created when it's module is installed
+type DefaultContent struct {
+	Entity   string `json:"entity"` // The entity type
+	UUID     string `json:"uuid"`
+	Bundle   string `json:"bundle"`
+	Title    string `json:"title"` // The value of the entity's label field, if it could be determined
+	FilePath string `json:"file_path"`
+}
+
+// GetDefaultContent gets the default content shipped in a module's content directory, sorted by file path.
+// Both the HAL JSON files of default_content 8.x-1.x and the YAML files of 2.x are understood.
+// Returns ErrModuleNotEnabled if the default_content module is not enabled.
+func (s Site) GetDefaultContent(moduleName string) ([]DefaultContent, error) {
+	err := s.requireModule("default_content")
+	if err != nil {
+		return nil, err
+	}
+
+	phpCode := "$path = drupal_get_path('module', " + phpString(moduleName) + "); " +
+		"if (!$path) { print json_encode(NULL); return; } " +
+		"$manager = \\Drupal::entityTypeManager(); " +
+		"$label = function ($entityType, $values) use ($manager) { $key = $manager->hasDefinition($entityType) ? $manager->getDefinition($entityType)->getKey('label') : ''; return $key && isset($values[$key][0]['value']) ? (string) $values[$key][0]['value'] : ''; }; " +
+		"$content = array(); " +
+		"$directory = DRUPAL_ROOT . '/' . $path . '/content/*/'; " +
+		"foreach (array_merge((glob($directory . '*.json') ?: array()), (glob($directory . '*.yml') ?: array())) as $file) { " +
+		"$entityType = basename(dirname($file)); " +
+		"if (substr($file, -4) == '.yml') { " +
+		"$data = \\Drupal\\Component\\Serialization\\Yaml::decode(file_get_contents($file)); " +
+		"$meta = isset($data['_meta']) ? $data['_meta'] : array(); " +
+		"$content[] = array('entity' => isset($meta['entity_type']) ? $meta['entity_type'] : $entityType, 'uuid' => isset($meta['uuid']) ? $meta['uuid'] : basename($file, '.yml'), 'bundle' => isset($meta['bundle']) ? $meta['bundle'] : '', 'title' => $label($entityType, isset($data['default']) ? $data['default'] : array()), 'file_path' => $file); " +
+		"} else { " +
+		"$data = json_decode(file_get_contents($file), TRUE); " +
+		"$bundle = isset($data['_links']['type']['href']) ? basename($data['_links']['type']['href']) : ''; " +
+		"$content[] = array('entity' => $entityType, 'uuid' => isset($data['uuid'][0]['value']) ? $data['uuid'][0]['value'] : basename($file, '.json'), 'bundle' => $bundle, 'title' => $label($entityType, $data), 'file_path' => $file); " +
+		"} " +
+		"} " +
+		"usort($content, function ($a, $b) { return strcmp($a['file_path'], $b['file_path']); }); " +
+		"print json_encode($content);"
+
+	var content *[]DefaultContent
+	err = s.phpEval(phpCode, &content)
+	if err != nil {
+		return nil, err
+	}
+	if content == nil {
+		return nil, errors.Newf("Module %v not found", moduleName)
+	}
+	return *content, nil
+}
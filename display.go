@@ -0,0 +1,41 @@
+package drupal
+
+// FormMode is an entity form mode, as defined in a core.entity_form_mode.* config object
+type FormMode struct {
+	ID               string `json:"id"`
+	Label            string `json:"label"`
+	TargetEntityType string `json:"targetEntityType"`
+}
+
+// ViewMode is an entity view mode, as defined in a core.entity_view_mode.* config object
+type ViewMode struct {
+	ID               string `json:"id"`
+	Label            string `json:"label"`
+	TargetEntityType string `json:"targetEntityType"`
+}
+
+// getDisplayModes decodes all form or view mode config objects with the given prefix into v
+func (s Site) getDisplayModes(prefix string, v interface{}) error {
+	phpCode := phpListConfig(prefix) + "$modes = array(); foreach ($configs as $data) { $modes[] = array('id' => $data['id'], 'label' => $data['label'], 'targetEntityType' => $data['targetEntityType']); } print json_encode($modes);"
+	return s.phpEval(phpCode, v)
+}
+
+// GetEntityFormModes gets all form modes defined for an entity type
+func (s Site) GetEntityFormModes(entityType string) ([]FormMode, error) {
+	var modes []FormMode
+	err := s.getDisplayModes("core.entity_form_mode."+entityType+".", &modes)
+	if err != nil {
+		return nil, err
+	}
+	return modes, nil
+}
+
+// GetEntityViewModes gets all view modes defined for an entity type
+func (s Site) GetEntityViewModes(entityType string) ([]ViewMode, error) {
+	var modes []ViewMode
+	err := s.getDisplayModes("core.entity_view_mode."+entityType+".", &modes)
+	if err != nil {
+		return nil, err
+	}
+	return modes, nil
+}
@@ -2,10 +2,13 @@ package drupal
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestDownload(t *testing.T) {
@@ -197,3 +200,486 @@ func TestDrush(t *testing.T) {
 	}
 
 }
+
+func TestDiffSettings(t *testing.T) {
+	a := Settings{"hash_salt": "A", "file_private_path": "/private", "update_free_access": false}
+	b := Settings{"hash_salt": "B", "file_private_path": "/private", "install_profile": "standard"}
+
+	diff, err := diffSettings(a, b)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := "- hash_salt: \"A\"\n+ hash_salt: \"B\"\n+ install_profile: \"standard\"\n- update_free_access: false\n"
+	if diff != expected {
+		t.Error("Bad settings diff. Got", diff)
+	}
+
+	diff, err = diffSettings(a, a)
+	if err != nil {
+		t.Error(err)
+	}
+	if diff != "" {
+		t.Error("Identical settings should produce an empty diff")
+	}
+}
+
+func TestPHPString(t *testing.T) {
+	if phpString("hello") != "'hello'" {
+		t.Error("Bad php string for plain string")
+	}
+	if phpString(`it's a \ test`) != `'it\'s a \\ test'` {
+		t.Error("Bad php string escaping. Got", phpString(`it's a \ test`))
+	}
+}
+
+func TestDrushCommandString(t *testing.T) {
+	drush := NewDrush("./test", "php-eval", "print 'hello';")
+
+	if !reflect.DeepEqual(drush.Args(), []string{"php-eval", "--yes", "--nocolor", "print 'hello';"}) {
+		t.Error("Bad drush args. Got", drush.Args())
+	}
+
+	expected := `drush php-eval --yes --nocolor 'print '\''hello'\'';'`
+	if drush.CommandString() != expected {
+		t.Error("Bad drush command string. Got", drush.CommandString())
+	}
+	if drush.DryRun() != expected {
+		t.Error("Bad drush dry run. Got", drush.DryRun())
+	}
+}
+
+func TestDependencyName(t *testing.T) {
+	dependencies := map[string]string{
+		"node":                    "node",
+		"drupal:node":             "node",
+		"ctools:ctools (>=8.x-3)": "ctools",
+		"views (>=8.3)":           "views",
+	}
+	for dependency, expected := range dependencies {
+		if dependencyName(dependency) != expected {
+			t.Error("Bad dependency name for", dependency, "Got", dependencyName(dependency))
+		}
+	}
+}
+
+func TestModuleDependents(t *testing.T) {
+	graph := map[string][]string{
+		"node":     {"text"},
+		"text":     {"field", "filter"},
+		"views":    {"node", "filter"},
+		"forum":    {"node", "taxonomy"},
+		"taxonomy": {"node", "text"},
+	}
+	enabled := map[string]int{"node": 0, "text": 0, "views": 10, "taxonomy": 0}
+
+	if !reflect.DeepEqual(moduleDependents(graph, enabled, "node"), []string{"taxonomy", "views"}) {
+		t.Error("Bad dependents for node. Got", moduleDependents(graph, enabled, "node"))
+	}
+	if len(moduleDependents(graph, enabled, "forum")) != 0 {
+		t.Error("forum should have no dependents")
+	}
+}
+
+func TestReadComposerInstalled(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-drupal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = os.MkdirAll(filepath.Join(dir, "vendor", "composer"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	installedPath := filepath.Join(dir, "vendor", "composer", "installed.json")
+
+	// Composer 1
+	err = ioutil.WriteFile(installedPath, []byte(`[{"name": "drush/drush", "version": "8.1.12", "type": "library", "license": ["GPL-2.0+"]}]`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	packages, err := readComposerInstalled(installedPath)
+	if err != nil {
+		t.Error(err)
+	}
+	if packages["drush/drush"] == nil || packages["drush/drush"].Version != "8.1.12" {
+		t.Error("Bad composer 1 package")
+	} else if packages["drush/drush"].Path != filepath.Join(dir, "vendor", "drush", "drush") {
+		t.Error("Bad composer 1 package path. Got", packages["drush/drush"].Path)
+	}
+
+	// Composer 2
+	err = ioutil.WriteFile(installedPath, []byte(`{"packages": [{"name": "drupal/core", "version": "8.9.0", "type": "drupal-core", "install-path": "../../web/core"}]}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	packages, err = readComposerInstalled(installedPath)
+	if err != nil {
+		t.Error(err)
+	}
+	if packages["drupal/core"] == nil || packages["drupal/core"].Type != "drupal-core" {
+		t.Error("Bad composer 2 package")
+	} else if packages["drupal/core"].Path != filepath.Join(dir, "web", "core") {
+		t.Error("Bad composer 2 package path. Got", packages["drupal/core"].Path)
+	}
+}
+
+func TestSettingsValidateSchema(t *testing.T) {
+	settings := Settings{
+		"hash_salt":                  "",
+		"trusted_host_patterns":      "^example\\.com$",
+		"skip_permissions_hardening": true,
+	}
+
+	problems := settings.ValidateSchema(DefaultProductionSchema)
+	expected := []SettingsValidationError{
+		{Key: "file_private_path", Expected: "string", Issue: "missing"},
+		{Key: "hash_salt", Expected: "string", Got: "string", Issue: "empty"},
+		{Key: "skip_permissions_hardening", Got: "bool", Issue: "prohibited"},
+		{Key: "trusted_host_patterns", Expected: "array", Got: "string", Issue: "wrong type"},
+	}
+	if !reflect.DeepEqual(problems, expected) {
+		t.Error("Bad production schema validation. Got", problems)
+	}
+
+	settings = Settings{"hash_salt": "HASH SALT TEST"}
+	if len(settings.ValidateSchema(DefaultDevelopmentSchema)) != 0 {
+		t.Error("Valid development settings should have no problems")
+	}
+}
+
+func TestDiffConfig(t *testing.T) {
+	active := map[string]interface{}{
+		"name":   "Drupal",
+		"page":   map[string]interface{}{"403": "", "404": "", "front": "/node"},
+		"slogan": "",
+	}
+	sync := map[string]interface{}{
+		"name": "Drupal",
+		"page": map[string]interface{}{"403": "", "404": "/not-found", "front": "/node"},
+		"mail": "admin@example.com",
+	}
+
+	entry := diffConfig(active, sync)
+	if !reflect.DeepEqual(entry.AddedKeys, []string{"mail"}) {
+		t.Error("Bad added keys. Got", entry.AddedKeys)
+	}
+	if !reflect.DeepEqual(entry.RemovedKeys, []string{"slogan"}) {
+		t.Error("Bad removed keys. Got", entry.RemovedKeys)
+	}
+	if !reflect.DeepEqual(entry.ChangedKeys, []string{"page.404"}) {
+		t.Error("Bad changed keys. Got", entry.ChangedKeys)
+	}
+}
+
+func TestPHPINIBytes(t *testing.T) {
+	sizes := map[string]int64{
+		"128M":    128 * 1024 * 1024,
+		"2g":      2 * 1024 * 1024 * 1024,
+		"512K":    512 * 1024,
+		"8388608": 8388608,
+		"-1":      -1,
+		"":        0,
+	}
+	for size, expected := range sizes {
+		if phpINIBytes(size) != expected {
+			t.Error("Bad bytes for", size, "Got", phpINIBytes(size))
+		}
+	}
+}
+
+func TestFindServerConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-drupal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	apache := "<VirtualHost *:80>\n  ServerName example.com\n  ServerAlias www.example.com\n</VirtualHost>\n"
+	nginx := "server {\n  listen 80;\n  server_name example.org www.example.org;\n}\n"
+	err = ioutil.WriteFile(filepath.Join(dir, "example.com.conf"), []byte(apache), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, "example.org"), []byte(nginx), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := findServerConfig([]string{"/nonexistent", dir}, []string{"servername", "serveralias"}, "www.example.com")
+	if err != nil {
+		t.Error(err)
+	}
+	if config != apache {
+		t.Error("Bad apache config. Got", config)
+	}
+
+	config, err = findServerConfig([]string{dir}, []string{"server_name"}, "www.example.org")
+	if err != nil {
+		t.Error(err)
+	}
+	if config != nginx {
+		t.Error("Bad nginx config. Got", config)
+	}
+
+	_, err = findServerConfig([]string{dir}, []string{"server_name"}, "example.com")
+	if !os.IsNotExist(err) {
+		t.Error("Expected not exist error for missing server config. Got", err)
+	}
+}
+
+func TestParseAccessLog(t *testing.T) {
+	log := `127.0.0.1 - - [10/Oct/2017:13:55:36 +0000] "GET /old HTTP/1.1" 500 512 "-" "curl/7.54.0" 0.900
+127.0.0.1 - - [11/Oct/2017:13:55:36 +0000] "GET / HTTP/1.1" 200 2326 "-" "Mozilla/5.0 (X11; Linux x86_64)" 0.250
+127.0.0.1 - - [11/Oct/2017:13:55:37 +0000] "GET /node/1 HTTP/1.1" 200 4096 "-" "curl/7.54.0" 0.150
+not a log line
+127.0.0.1 - - [11/Oct/2017:13:55:38 +0000] "POST /user/login HTTP/1.1" 503 0 "-" "curl/7.54.0" 0.200
+127.0.0.1 - - [11/Oct/2017:13:55:39 +0000] "GET /node/2 HTTP/1.1" 404 128 "-" "curl/7.54.0" 0.100
+`
+	since := time.Date(2017, 10, 11, 0, 0, 0, 0, time.UTC)
+	metrics, err := parseAccessLog(strings.NewReader(log), since)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if metrics.TotalRequests != 4 {
+		t.Error("Bad total requests. Got", metrics.TotalRequests)
+	}
+	if metrics.FailedRequests != 1 {
+		t.Error("Bad failed requests. Got", metrics.FailedRequests)
+	}
+	if metrics.UptimePercent != 75 {
+		t.Error("Bad uptime percent. Got", metrics.UptimePercent)
+	}
+	if metrics.AverageResponseTime < 0.1749 || metrics.AverageResponseTime > 0.1751 {
+		t.Error("Bad average response time. Got", metrics.AverageResponseTime)
+	}
+}
+
+func TestNormalizeConfig(t *testing.T) {
+	config := map[string]interface{}{
+		"uuid":     "8d2d8f1c-5b2f-4b1e-9a4e-0b6f6e3c2a1d",
+		"_core":    map[string]interface{}{"default_config_hash": "abc"},
+		"langcode": "en",
+		"name":     "Drupal",
+	}
+
+	if !reflect.DeepEqual(normalizeConfig(config, "en"), map[string]interface{}{"name": "Drupal"}) {
+		t.Error("Bad normalized config. Got", normalizeConfig(config, "en"))
+	}
+	if !reflect.DeepEqual(normalizeConfig(config, "fr"), map[string]interface{}{"langcode": "en", "name": "Drupal"}) {
+		t.Error("Bad normalized config with non-default langcode. Got", normalizeConfig(config, "fr"))
+	}
+}
+
+func TestParseSettingsPHP(t *testing.T) {
+	src := `<?php
+// Local settings
+$databases['default']['default'] = array('driver' => 'mysql');
+$settings['hash_salt'] = 'HASH SALT TEST';
+$settings['update_free_access'] = FALSE;
+$settings['container_yamls'][] = $app_root . '/' . $site_path . '/services.yml';
+$settings['file_scan_ignore_directories'] = [
+  'node_modules',
+  'bower_components',
+];
+# Concatenated and escaped strings
+$settings['file_private_path'] = '/var/' . 'private';
+$settings['site_name'] = "It's \"quoted\"";
+/* Nested keys */
+$settings['cache']['bins']['render'] = 'cache.backend.null';
+$settings['entity_update_batch_size'] = 50;
+if (file_exists(__DIR__ . '/settings.local.php')) {
+  $settings['trusted_host_patterns'] = array('^example\.com$', );
+}
+$settings['skip_permissions_hardening'] = $debug;
+`
+	settings := parseSettingsPHP(src)
+	expected := Settings{
+		"hash_salt":                    "HASH SALT TEST",
+		"update_free_access":           false,
+		"file_scan_ignore_directories": []interface{}{"node_modules", "bower_components"},
+		"file_private_path":            "/var/private",
+		"site_name":                    `It's "quoted"`,
+		"cache":                        map[string]interface{}{"bins": map[string]interface{}{"render": "cache.backend.null"}},
+		"entity_update_batch_size":     float64(50),
+		"trusted_host_patterns":        []interface{}{`^example\.com$`},
+	}
+	if !reflect.DeepEqual(settings, expected) {
+		t.Error("Bad settings parsed from file. Got", settings)
+	}
+}
+
+func TestParseCSP(t *testing.T) {
+	csp := parseCSP("default-src 'self'; script-src 'self' 'unsafe-inline' https://cdn.example.com;img-src *; upgrade-insecure-requests; script-src 'none'")
+
+	expected := &CSPDirectives{
+		DefaultSrc: []string{"'self'"},
+		ScriptSrc:  []string{"'self'", "'unsafe-inline'", "https://cdn.example.com"},
+		StyleSrc:   []string{},
+		ImgSrc:     []string{"*"},
+		ConnectSrc: []string{},
+	}
+	if !reflect.DeepEqual(csp, expected) {
+		t.Error("Bad parsed CSP. Got", csp)
+	}
+}
+
+func TestMenuAccessibilityIssues(t *testing.T) {
+	links := []MenuLink{
+		{ID: "home", Title: "Home", URL: "/", Enabled: true},
+		{ID: "about", Title: "About", URL: "/about", Enabled: true},
+		{ID: "empty", Title: " ", URL: "/empty", Enabled: true},
+		{ID: "about-again", Title: "about", URL: "/about-us", Enabled: true},
+		{ID: "team", Title: "About", URL: "/about", ParentID: "about", Enabled: true},
+		{ID: "disabled", Title: "", URL: "/", Enabled: false},
+	}
+
+	expected := []AccessibilityIssue{
+		{ElementType: "menu_link", ElementID: "empty", Issue: "Link has no title", Severity: "error"},
+		{ElementType: "menu_link", ElementID: "about-again", Issue: "Link has the same title as sibling link about", Severity: "warning"},
+		{ElementType: "menu_link", ElementID: "team", Issue: "Link has the same URL as link about", Severity: "warning"},
+	}
+	issues := menuAccessibilityIssues(links)
+	if !reflect.DeepEqual(issues, expected) {
+		t.Error("Bad menu accessibility issues. Got", issues)
+	}
+}
+
+func TestTrustedHost(t *testing.T) {
+	patterns := map[string]string{
+		`^www\.example\.com$`:    "www.example.com",
+		`^my\-site\.local$`:      "my-site.local",
+		`^.+\.example\.com$`:     "",
+		`^(www\.)?example\.org$`: "",
+	}
+	for pattern, expected := range patterns {
+		host, ok := trustedHost(pattern)
+		if host != expected || ok != (expected != "") {
+			t.Error("Bad trusted host for", pattern, "Got", host)
+		}
+	}
+}
+
+func TestParseSQLVariable(t *testing.T) {
+	output := "Variable_name\tValue\ncharacter_set_database\tutf8mb4\n"
+
+	value, ok := parseSQLVariable(output, "character_set_database")
+	if !ok || value != "utf8mb4" {
+		t.Error("Bad sql variable. Got", value)
+	}
+	_, ok = parseSQLVariable(output, "collation_database")
+	if ok {
+		t.Error("Missing sql variable should not be found")
+	}
+}
+
+func TestParseDrushCommandList(t *testing.T) {
+	output := `{"application": {"name": "Drush Commandline Tool"}, "commands": [
+		{"name": "cache:rebuild", "aliases": ["cr", "rebuild"], "description": "Rebuild a Drupal 8 site.", "definition": {"arguments": [], "options": {"cache-clear": {"name": "--cache-clear", "description": "Clear caches."}}}},
+		{"name": "config:get", "aliases": ["cget"], "description": "Display a config value.", "definition": {"arguments": {"config_name": {"name": "config_name", "description": "The config object name."}}, "options": []}}
+	]}`
+
+	commands, err := parseDrushCommandList([]byte(output))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []DrushCommand{
+		{Name: "cache:rebuild", Aliases: []string{"cr", "rebuild"}, Description: "Rebuild a Drupal 8 site.", Arguments: map[string]string{}, Options: map[string]string{"cache-clear": "Clear caches."}},
+		{Name: "config:get", Aliases: []string{"cget"}, Description: "Display a config value.", Arguments: map[string]string{"config_name": "The config object name."}, Options: map[string]string{}},
+	}
+	if !reflect.DeepEqual(commands, expected) {
+		t.Error("Bad drush command list. Got", commands)
+	}
+}
+
+func TestValidateFrontController(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-drupal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = ioutil.WriteFile(filepath.Join(dir, "index.php"), []byte("<?php\n$autoloader = require_once 'autoload.php';\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, "autoload.php"), []byte("<?php\nreturn require __DIR__ . '/vendor/autoload.php';\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if validateFrontController(dir) == nil {
+		t.Error("Missing composer autoloader should be invalid")
+	}
+
+	err = os.MkdirAll(filepath.Join(dir, "vendor"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, "vendor", "autoload.php"), []byte("<?php\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = validateFrontController(dir)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetMultipleEntityFieldValuesEmpty(t *testing.T) {
+	site := Site("./test")
+
+	values, err := site.GetMultipleEntityFieldValues("node", nil, []string{"title"})
+	if err != nil {
+		t.Error(err)
+	}
+	if values == nil || len(values) != 0 {
+		t.Error("No ids should get an empty map. Got", values)
+	}
+
+	values, err = site.GetMultipleEntityFieldValues("node", []int{1}, nil)
+	if err != nil {
+		t.Error(err)
+	}
+	if values == nil || len(values) != 0 {
+		t.Error("No fields should get an empty map. Got", values)
+	}
+}
+
+func TestSetCronIntervalInvalid(t *testing.T) {
+	site := Site("./test")
+
+	for _, interval := range []time.Duration{-time.Second, time.Millisecond, 999 * time.Millisecond} {
+		if err := site.SetCronInterval(interval); err == nil {
+			t.Error("Expected error for cron interval", interval)
+		}
+	}
+}
+
+func TestExportContentToCSVNoFields(t *testing.T) {
+	site := Site("./test")
+
+	err := site.ExportContentToCSV("node", "article", nil, ioutil.Discard)
+	if err == nil {
+		t.Error("Expected error exporting no fields")
+	}
+}
+
+func TestModuleHookCandidates(t *testing.T) {
+	functions := []string{"foo_help", "foo_form_alter", "foo_bar_help", "foo_bar_node_insert", "foobar_help", "foo_"}
+	modules := []string{"foo", "foo_bar", "foobar", "node"}
+
+	candidates := moduleHookCandidates("foo", functions, modules)
+	expected := []string{"form_alter", "help"}
+	if !reflect.DeepEqual(candidates, expected) {
+		t.Error("Bad hook candidates for foo. Got", candidates)
+	}
+
+	candidates = moduleHookCandidates("foo_bar", functions, modules)
+	expected = []string{"help", "node_insert"}
+	if !reflect.DeepEqual(candidates, expected) {
+		t.Error("Bad hook candidates for foo_bar. Got", candidates)
+	}
+}
@@ -15,6 +15,8 @@ type Drush struct {
 	Command   string
 	Arguments []string
 	cmd       *exec.Cmd
+
+	memoryLimit string // php memory_limit for the command, if set by RunWithMemoryLimit
 }
 
 // NewDrush returns a new drush command
@@ -96,13 +98,80 @@ func (d *Drush) Run() (output string, messages DrushMessages, errs error) {
 	return outbuf.String(), messages, errs
 }
 
+// RunWithMemoryLimit executes the drush command with a php memory_limit (eg "512M" or "-1" for unlimited), overriding php.ini.
+// If drush is a php script (drush 9 and later, or the drush.php entry point) it is run as "php -d memory_limit=limit drush ...".
+// Otherwise drush is assumed to be the drush 8 shell launcher and the limit is passed using the PHP_OPTIONS environment variable, which only that launcher honours.
+func (d *Drush) RunWithMemoryLimit(limit string) (output string, messages DrushMessages, errs error) {
+	d.memoryLimit = limit
+	defer func() { d.memoryLimit = "" }()
+	return d.Run()
+}
+
+// Args returns the full list of arguments that will be passed to drush, including global options
+func (d *Drush) Args() []string {
+	d.buildCommand()
+	return d.cmd.Args[1:]
+}
+
+// CommandString returns the drush command line that will be executed, suitable for display
+func (d *Drush) CommandString() string {
+	d.buildCommand()
+
+	quoted := make([]string, len(d.cmd.Args))
+	for i, arg := range d.cmd.Args {
+		quoted[i] = shellQuote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// DryRun returns the drush command line that would be executed, without executing it.
+// It is an alias for CommandString.
+func (d *Drush) DryRun() string {
+	return d.CommandString()
+}
+
+// shellQuote single-quotes an argument for display if it contains characters special to the shell
+func shellQuote(arg string) string {
+	if arg != "" && strings.Trim(arg, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_=.,:/@%+") == "" {
+		return arg
+	}
+	return "'" + strings.Replace(arg, "'", `'\''`, -1) + "'"
+}
+
 func (d *Drush) buildCommand() {
 	global := []string{d.Command, "--yes", "--nocolor"}
 	arguments := append(global, d.Arguments...)
 
 	d.cmd = exec.Command("drush", arguments...)
-	d.cmd.Dir = d.Directory
 	d.cmd.Env = append(os.Environ(), "DRUSH_COLUMNS=10000", "COLUMNS=10000")
+	if d.memoryLimit != "" {
+		if path, ok := drushPHPScript(); ok {
+			d.cmd = exec.Command("php", append([]string{"-d", "memory_limit=" + d.memoryLimit, path}, arguments...)...)
+			d.cmd.Env = append(os.Environ(), "DRUSH_COLUMNS=10000", "COLUMNS=10000")
+		} else {
+			d.cmd.Env = append(d.cmd.Env, "PHP_OPTIONS="+strings.TrimSpace(os.Getenv("PHP_OPTIONS")+" -d memory_limit="+d.memoryLimit))
+		}
+	}
+	d.cmd.Dir = d.Directory
+}
+
+// drushPHPScript finds drush in the PATH and checks if it is a php script that can be run with the php executable
+func drushPHPScript() (string, bool) {
+	path, err := exec.LookPath("drush")
+	if err != nil {
+		return "", false
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return "", false
+	}
+	defer file.Close()
+
+	line, _ := bufio.NewReader(file).ReadString('\n')
+	if strings.HasPrefix(line, "<?php") || (strings.HasPrefix(line, "#!") && strings.Contains(line, "php")) {
+		return path, true
+	}
+	return "", false
 }
 
 // DrushMessage implements the standard error interface and represents a single line in stdout
@@ -0,0 +1,394 @@
+package drupal
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/phayes/errors"
+)
+
+// phpLoadEntity returns php code that loads an entity into $entity, printing null and returning if it does not exist
+func phpLoadEntity(entityType string, id int) string {
+	return "$entity = \\Drupal::entityTypeManager()->getStorage(" + phpString(entityType) + ")->load(" + strconv.Itoa(id) + "); if (!$entity) { print json_encode(NULL); return; } "
+}
+
+// GetEntityReference gets the target entity IDs of an entity reference field on an entity
+func (s Site) GetEntityReference(entityType string, id int, field string) ([]int, error) {
+	phpCode := phpLoadEntity(entityType, id) + "print json_encode(array_map(function ($item) { return (int) $item['target_id']; }, $entity->get(" + phpString(field) + ")->getValue()));"
+
+	var targets *[]int
+	err := s.phpEval(phpCode, &targets)
+	if err != nil {
+		return nil, err
+	}
+	if targets == nil {
+		return nil, errors.Wrapf(ErrEntityNotFound, "Could not load %v %v", entityType, id)
+	}
+	return *targets, nil
+}
+
+// GetEntityReferenceEntities loads the entities referenced by an entity reference field on an entity.
+// Each referenced entity is returned as an array of it's field values.
+func (s Site) GetEntityReferenceEntities(entityType string, id int, field string, targetEntityType string) ([]map[string]interface{}, error) {
+	targets, err := s.GetEntityReference(entityType, id, field)
+	if err != nil {
+		return nil, err
+	}
+	if len(targets) == 0 {
+		return []map[string]interface{}{}, nil
+	}
+
+	ids, err := phpValue(targets)
+	if err != nil {
+		return nil, err
+	}
+	phpCode := "$entities = \\Drupal::entityTypeManager()->getStorage(" + phpString(targetEntityType) + ")->loadMultiple(" + ids + "); print json_encode(array_values(array_map(function ($entity) { return $entity->toArray(); }, $entities)));"
+
+	var entities []map[string]interface{}
+	err = s.phpEval(phpCode, &entities)
+	if err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+// phpViolations is php code defining a $violations closure that formats an entity's constraint violations as strings
+const phpViolations = "$violations = function ($entity) { $messages = array(); foreach ($entity->validate() as $violation) { $messages[] = trim($violation->getPropertyPath() . ': ' . strip_tags((string) $violation->getMessage()), ': '); } return $messages; }; "
+
+// GetEntityValidationErrors validates an entity and gets any constraint violations, formatted as "field: message"
+func (s Site) GetEntityValidationErrors(entityType string, id int) ([]string, error) {
+	phpCode := phpViolations + phpLoadEntity(entityType, id) + "print json_encode($violations($entity));"
+
+	var violations *[]string
+	err := s.phpEval(phpCode, &violations)
+	if err != nil {
+		return nil, err
+	}
+	if violations == nil {
+		return nil, errors.Wrapf(ErrEntityNotFound, "Could not load %v %v", entityType, id)
+	}
+	return *violations, nil
+}
+
+// ValidateAllEntities validates every entity of an entity type, and gets the constraint violations keyed by entity ID.
+// Only entities with at least one violation are included.
+func (s Site) ValidateAllEntities(entityType string) (map[int][]string, error) {
+	phpCode := phpViolations +
+		"$storage = \\Drupal::entityTypeManager()->getStorage(" + phpString(entityType) + "); " +
+		"$invalid = array(); " +
+		"foreach (array_chunk($storage->getQuery()->accessCheck(FALSE)->execute(), 50) as $ids) { " +
+		"foreach ($storage->loadMultiple($ids) as $id => $entity) { $messages = $violations($entity); if ($messages) { $invalid[$id] = $messages; } } " +
+		"$storage->resetCache($ids); " +
+		"} " +
+		"print json_encode((object) $invalid);"
+
+	var invalid map[int][]string
+	err := s.phpEval(phpCode, &invalid)
+	if err != nil {
+		return nil, err
+	}
+	return invalid, nil
+}
+
+// GetEntityWithRelated loads an entity as an array of field values, along with the entities it references up to depth levels deep.
+// Each item of an entity reference field has the referenced entity added under the "entity" key.
+// Every entity is only included once, so references that would form a cycle, or that point to an entity already included elsewhere, only contain the "target_id".
+func (s Site) GetEntityWithRelated(entityType string, id int, depth int) (map[string]interface{}, error) {
+	phpCode := "$seen = array(); " +
+		"$load = function ($entity, $depth) use (&$load, &$seen) { " +
+		"$seen[$entity->getEntityTypeId() . ':' . $entity->id()] = TRUE; " +
+		"$values = $entity->toArray(); " +
+		"if ($depth <= 0) { return $values; } " +
+		"foreach ($entity->getFields() as $name => $items) { " +
+		"if (!($items instanceof \\Drupal\\Core\\Field\\EntityReferenceFieldItemListInterface)) { continue; } " +
+		"foreach ($items as $delta => $item) { " +
+		"if (!$item->entity || isset($seen[$item->entity->getEntityTypeId() . ':' . $item->entity->id()])) { continue; } " +
+		"$values[$name][$delta]['entity'] = $load($item->entity, $depth - 1); " +
+		"} " +
+		"} " +
+		"return $values; " +
+		"}; " +
+		phpLoadEntity(entityType, id) +
+		"print json_encode($load($entity, " + strconv.Itoa(depth) + "));"
+
+	var entity map[string]interface{}
+	err := s.phpEval(phpCode, &entity)
+	if err != nil {
+		return nil, err
+	}
+	if entity == nil {
+		return nil, errors.Wrapf(ErrEntityNotFound, "Could not load %v %v", entityType, id)
+	}
+	return entity, nil
+}
+
+// GetEntityWithAllTranslations gets the translatable field values of every translation of an entity, keyed by language code.
+// Entities that are not translatable only have their original language.
+func (s Site) GetEntityWithAllTranslations(entityType string, id int) (map[string]map[string]interface{}, error) {
+	phpCode := phpLoadEntity(entityType, id) +
+		"$translations = array(); " +
+		"foreach ($entity->getTranslationLanguages() as $langcode => $language) { " +
+		"$values = array(); " +
+		"foreach ($entity->getTranslation($langcode)->getTranslatableFields(FALSE) as $name => $items) { $values[$name] = $items->getValue(); } " +
+		"$translations[$langcode] = (object) $values; " +
+		"} " +
+		"print json_encode((object) $translations);"
+
+	var translations map[string]map[string]interface{}
+	err := s.phpEval(phpCode, &translations)
+	if err != nil {
+		return nil, err
+	}
+	if translations == nil {
+		return nil, errors.Wrapf(ErrEntityNotFound, "Could not load %v %v", entityType, id)
+	}
+	return translations, nil
+}
+
+// phpFieldValues is a php closure that gets the values of the fields named in $fieldNames from an entity. Fields the entity does not have are omitted.
+const phpFieldValues = "$fieldValues = function ($entity) use ($fieldNames) { $values = array(); foreach ($fieldNames as $name) { if ($entity->hasField($name)) { $values[$name] = $entity->get($name)->getValue(); } } return (object) $values; }; "
+
+// GetEntityFieldValues gets the values of some fields of an entity, keyed by field name. Fields the entity does not have are omitted.
+func (s Site) GetEntityFieldValues(entityType string, id int, fields []string) (map[string]interface{}, error) {
+	if fields == nil {
+		fields = []string{}
+	}
+	phpFields, err := phpValue(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	phpCode := "$fieldNames = " + phpFields + "; " + phpFieldValues +
+		phpLoadEntity(entityType, id) +
+		"print json_encode($fieldValues($entity));"
+
+	var values map[string]interface{}
+	err = s.phpEval(phpCode, &values)
+	if err != nil {
+		return nil, err
+	}
+	if values == nil {
+		return nil, errors.Wrapf(ErrEntityNotFound, "Could not load %v %v", entityType, id)
+	}
+	return values, nil
+}
+
+// GetMultipleEntityFieldValues gets the values of some fields of several entities, keyed by entity ID and then field name.
+// Entities that do not exist and fields they do not have are omitted, so no ids or no fields gets an empty map.
+func (s Site) GetMultipleEntityFieldValues(entityType string, ids []int, fields []string) (map[int]map[string]interface{}, error) {
+	// loadMultiple(NULL) would load every entity of the type
+	if len(ids) == 0 || len(fields) == 0 {
+		return map[int]map[string]interface{}{}, nil
+	}
+
+	phpFields, err := phpValue(fields)
+	if err != nil {
+		return nil, err
+	}
+	phpIDs, err := phpValue(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	phpCode := "$fieldNames = " + phpFields + "; " + phpFieldValues +
+		"print json_encode((object) array_map($fieldValues, \\Drupal::entityTypeManager()->getStorage(" + phpString(entityType) + ")->loadMultiple(" + phpIDs + ")));"
+
+	var values map[int]map[string]interface{}
+	err = s.phpEval(phpCode, &values)
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// DeleteImpact describes what would be affected by deleting an entity
+type DeleteImpact struct {
+	DependentEntities []struct {
+		EntityType string `json:"entity_type"`
+		IDs        []int  `json:"ids"`
+	} `json:"dependent_entities"` // Entities with an entity reference field that references the entity, grouped by entity type
+	Files     []int `json:"files"`     // Files recorded as used by the entity in the file usage table
+	Redirects []int `json:"redirects"` // Redirects to the entity, if the redirect module is enabled
+}
+
+// GetEntityDeleteImpact finds the entities, files and redirects that reference an entity, and so would be affected by deleting it.
+// Drupal has no service for this, so every entity reference field that targets the entity's type is queried.
+func (s Site) GetEntityDeleteImpact(entityType string, id int) (*DeleteImpact, error) {
+	phpCode := phpLoadEntity(entityType, id) +
+		"$dependents = array(); " +
+		"foreach (\\Drupal::service('entity_field.manager')->getFieldMapByFieldType('entity_reference') as $type => $fields) { " +
+		"$definitions = \\Drupal::service('entity_field.manager')->getFieldStorageDefinitions($type); " +
+		"$ids = array(); " +
+		"foreach (array_keys($fields) as $field) { " +
+		"if (!isset($definitions[$field]) || $definitions[$field]->getSetting('target_type') != $entity->getEntityTypeId() || $definitions[$field]->hasCustomStorage()) { continue; } " +
+		"$ids = array_merge($ids, array_values(\\Drupal::entityQuery($type)->accessCheck(FALSE)->condition($field . '.target_id', $entity->id())->execute())); " +
+		"} " +
+		"if ($ids) { $dependents[] = array('entity_type' => $type, 'ids' => array_map('intval', array_values(array_unique($ids)))); } " +
+		"} " +
+		"$files = array_map('intval', \\Drupal::database()->select('file_usage', 'f')->fields('f', array('fid'))->condition('type', $entity->getEntityTypeId())->condition('id', $entity->id())->distinct()->execute()->fetchCol()); " +
+		"$redirects = array(); " +
+		"if (\\Drupal::moduleHandler()->moduleExists('redirect')) { " +
+		"$path = $entity->getEntityTypeId() . '/' . $entity->id(); " +
+		"$redirects = array_map('intval', array_values(\\Drupal::entityQuery('redirect')->accessCheck(FALSE)->condition('redirect_redirect.uri', array('entity:' . $path, 'internal:/' . $path), 'IN')->execute())); " +
+		"} " +
+		"print json_encode(array('dependent_entities' => $dependents, 'files' => $files, 'redirects' => $redirects));"
+
+	var impact *DeleteImpact
+	err := s.phpEval(phpCode, &impact)
+	if err != nil {
+		return nil, err
+	}
+	if impact == nil {
+		return nil, errors.Wrapf(ErrEntityNotFound, "Could not load %v %v", entityType, id)
+	}
+	return impact, nil
+}
+
+// GetEntitySchemaQueries gets the names of the database tables that store an entity type, keyed by
+// "baseTable", "revisionTable", "dataTable" and "revisionDataTable". Tables the entity type does not use are empty.
+// Table names do not include the database prefix, see GetEntityTablePrefix.
+func (s Site) GetEntitySchemaQueries(entityType string) (map[string]string, error) {
+	phpCode := "$definition = \\Drupal::entityTypeManager()->getDefinition(" + phpString(entityType) + "); " +
+		"print json_encode(array('baseTable' => (string) $definition->getBaseTable(), 'revisionTable' => (string) $definition->getRevisionTable(), 'dataTable' => (string) $definition->getDataTable(), 'revisionDataTable' => (string) $definition->getRevisionDataTable()));"
+
+	var tables map[string]string
+	err := s.phpEval(phpCode, &tables)
+	if err != nil {
+		return nil, err
+	}
+	return tables, nil
+}
+
+// GetEntityTablePrefix gets the database table prefix used for an entity type's base table
+func (s Site) GetEntityTablePrefix(entityType string) (string, error) {
+	phpCode := "$definition = \\Drupal::entityTypeManager()->getDefinition(" + phpString(entityType) + "); " +
+		"print json_encode((string) \\Drupal::database()->tablePrefix((string) $definition->getBaseTable()));"
+
+	var prefix string
+	err := s.phpEval(phpCode, &prefix)
+	if err != nil {
+		return "", err
+	}
+	return prefix, nil
+}
+
+// BrokenReference is an entity reference field value that points to an entity that no longer exists
+type BrokenReference struct {
+	SourceEntityType string `json:"source_entity_type"`
+	SourceEntityID   int    `json:"source_entity_id"`
+	FieldName        string `json:"field_name"`
+	TargetEntityType string `json:"target_entity_type"`
+	TargetEntityID   int    `json:"target_entity_id"`
+}
+
+// GetBrokenEntityReferences finds values of entity reference fields on an entity type that point to deleted entities, up to limit references.
+// A limit of 0 finds all broken references. Only configurable fields referencing content entities are checked.
+// This queries every reference field's table, so it can be slow on large sites.
+func (s Site) GetBrokenEntityReferences(entityType string, limit int) ([]BrokenReference, error) {
+	phpCode := "$manager = \\Drupal::entityTypeManager(); " +
+		"$mapping = $manager->getStorage(" + phpString(entityType) + ")->getTableMapping(); " +
+		"$database = \\Drupal::database(); " +
+		"$limit = " + strconv.Itoa(limit) + "; " +
+		"$broken = array(); " +
+		"foreach (\\Drupal::service('entity_field.manager')->getFieldStorageDefinitions(" + phpString(entityType) + ") as $name => $definition) { " +
+		"if (!in_array($definition->getType(), array('entity_reference', 'file', 'image')) || !$mapping->requiresDedicatedTableStorage($definition)) { continue; } " +
+		"$targetType = $manager->getDefinition($definition->getSetting('target_type')); " +
+		"if (!$targetType->getBaseTable()) { continue; } " +
+		"$column = $mapping->getFieldColumnName($definition, 'target_id'); " +
+		"$query = $database->select($mapping->getDedicatedDataTableName($definition), 'f')->fields('f', array('entity_id', $column))->distinct(); " +
+		"$query->leftJoin($targetType->getBaseTable(), 't', 'f.' . $column . ' = t.' . $targetType->getKey('id')); " +
+		"$query->isNull('t.' . $targetType->getKey('id'))->orderBy('f.entity_id'); " +
+		"if ($limit > 0) { $query->range(0, $limit - count($broken)); } " +
+		"foreach ($query->execute() as $row) { " +
+		"$broken[] = array('source_entity_type' => " + phpString(entityType) + ", 'source_entity_id' => (int) $row->entity_id, 'field_name' => $name, 'target_entity_type' => $targetType->id(), 'target_entity_id' => (int) $row->$column); " +
+		"} " +
+		"if ($limit > 0 && count($broken) >= $limit) { break; } " +
+		"} " +
+		"print json_encode($broken);"
+
+	var broken []BrokenReference
+	err := s.phpEval(phpCode, &broken)
+	if err != nil {
+		return nil, err
+	}
+	return broken, nil
+}
+
+// FixBrokenEntityReferences removes every broken entity reference found by GetBrokenEntityReferences, saving the affected entities.
+// If dryRun is true nothing is changed. Returns the number of broken references that were, or would be, removed.
+func (s Site) FixBrokenEntityReferences(entityType string, dryRun bool) (int, error) {
+	broken, err := s.GetBrokenEntityReferences(entityType, 0)
+	if err != nil {
+		return 0, err
+	}
+	if dryRun || len(broken) == 0 {
+		return len(broken), nil
+	}
+
+	phpBroken, err := phpValue(broken)
+	if err != nil {
+		return 0, err
+	}
+	phpCode := "$storage = \\Drupal::entityTypeManager()->getStorage(" + phpString(entityType) + "); " +
+		"$targets = array(); foreach (" + phpBroken + " as $reference) { $targets[$reference['source_entity_id']][$reference['field_name']][] = $reference['target_entity_id']; } " +
+		"foreach ($storage->loadMultiple(array_keys($targets)) as $id => $entity) { " +
+		"foreach ($targets[$id] as $field => $targetIDs) { $entity->get($field)->filter(function ($item) use ($targetIDs) { return !in_array($item->target_id, $targetIDs); }); } " +
+		"$entity->save(); " +
+		"}"
+	err = s.phpEval(phpCode, nil)
+	if err != nil {
+		return 0, err
+	}
+	return len(broken), nil
+}
+
+// GetEntityJSON serializes an entity to JSON using drupal's serializer service, as the core REST module would
+func (s Site) GetEntityJSON(entityType string, id int) ([]byte, error) {
+	phpCode := phpLoadEntity(entityType, id) + "print \\Drupal::service('serializer')->serialize($entity, 'json');"
+
+	var out json.RawMessage
+	err := s.phpEval(phpCode, &out)
+	if err != nil {
+		return nil, err
+	}
+	if string(out) == "null" {
+		return nil, errors.Wrapf(ErrEntityNotFound, "Could not load %v %v", entityType, id)
+	}
+	return out, nil
+}
+
+// GetEntityJSONAPI gets the JSON:API document for an entity by handling a GET request to it's JSON:API resource as a sub-request inside drupal.
+// No HTTP request is made, but the sub-request is subject to access checks as the anonymous user.
+// Resource types and paths altered by jsonapi_extras are respected.
+// Returns ErrModuleNotEnabled if the jsonapi module is not enabled.
+func (s Site) GetEntityJSONAPI(entityType string, id int) ([]byte, error) {
+	err := s.requireModule("jsonapi")
+	if err != nil {
+		return nil, err
+	}
+
+	phpCode := phpLoadEntity(entityType, id) +
+		"$resourceType = \\Drupal::service('jsonapi.resource_type.repository')->get($entity->getEntityTypeId(), $entity->bundle()); " +
+		"$basePath = \\Drupal::getContainer()->hasParameter('jsonapi.base_path') ? \\Drupal::getContainer()->getParameter('jsonapi.base_path') : '/jsonapi'; " +
+		"$path = $basePath . (method_exists($resourceType, 'getPath') ? $resourceType->getPath() : '/' . $entity->getEntityTypeId() . '/' . $entity->bundle()) . '/' . $entity->uuid(); " +
+		"$request = \\Symfony\\Component\\HttpFoundation\\Request::create($path, 'GET', array(), array(), array(), array('HTTP_ACCEPT' => 'application/vnd.api+json')); " +
+		"$response = \\Drupal::service('http_kernel')->handle($request, \\Symfony\\Component\\HttpKernel\\HttpKernelInterface::SUB_REQUEST); " +
+		"print json_encode(array('path' => $path, 'status' => $response->getStatusCode(), 'body' => $response->getContent()));"
+
+	var response *struct {
+		Path   string `json:"path"`
+		Status int    `json:"status"`
+		Body   string `json:"body"`
+	}
+	err = s.phpEval(phpCode, &response)
+	if err != nil {
+		return nil, err
+	}
+	if response == nil {
+		return nil, errors.Wrapf(ErrEntityNotFound, "Could not load %v %v", entityType, id)
+	}
+	if response.Status != 200 {
+		return nil, errors.Newf("JSON:API request to %v failed with status %v", response.Path, response.Status)
+	}
+	return []byte(response.Body), nil
+}
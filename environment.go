@@ -0,0 +1,125 @@
+package drupal
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/phayes/errors"
+)
+
+// GetEnvironmentURLs gets the URLs of the site's environments, keyed by environment name.
+// On Pantheon these are the dev, test and live URLs. On Platform.sh only the current environment is known, using the primary route.
+// Otherwise each literal host in the trusted_host_patterns setting is included, keyed by it's host name.
+func (s Site) GetEnvironmentURLs() (map[string]string, error) {
+	if s.IsPantheonSite() {
+		return s.GetPantheonEnvironmentURLs()
+	}
+
+	urls := map[string]string{}
+
+	if routes := os.Getenv("PLATFORM_ROUTES"); routes != "" {
+		decoded, err := base64.StdEncoding.DecodeString(routes)
+		if err != nil {
+			return nil, errors.Wraps(err, "Error decoding PLATFORM_ROUTES")
+		}
+		var routeMap map[string]struct {
+			Primary bool `json:"primary"`
+		}
+		err = json.Unmarshal(decoded, &routeMap)
+		if err != nil {
+			return nil, errors.Wraps(err, "Error decoding PLATFORM_ROUTES")
+		}
+		for url, route := range routeMap {
+			if route.Primary {
+				urls[os.Getenv("PLATFORM_ENVIRONMENT")] = strings.TrimRight(url, "/")
+			}
+		}
+		return urls, nil
+	}
+
+	settings, err := s.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+	// GetArray panics if the setting is not a list
+	if _, ok := settings["trusted_host_patterns"].([]interface{}); !ok {
+		return urls, nil
+	}
+	for _, pattern := range settings.GetArray("trusted_host_patterns") {
+		host, ok := trustedHost(pattern)
+		if ok {
+			urls[host] = "https://" + host
+		}
+	}
+	return urls, nil
+}
+
+// GetEnvironmentURL gets the URL of a single environment, as returned by GetEnvironmentURLs
+func (s Site) GetEnvironmentURL(envName string) (string, error) {
+	urls, err := s.GetEnvironmentURLs()
+	if err != nil {
+		return "", err
+	}
+
+	url, ok := urls[envName]
+	if !ok {
+		return "", errors.Newf("No URL found for environment %v", envName)
+	}
+	return url, nil
+}
+
+// trustedHost converts a trusted_host_patterns regular expression that matches a single host, such as "^www\.example\.com$", to that host
+func trustedHost(pattern string) (string, bool) {
+	host := strings.TrimSuffix(strings.TrimPrefix(pattern, "^"), "$")
+	host = strings.Replace(host, `\.`, ".", -1)
+	host = strings.Replace(host, `\-`, "-", -1)
+	if host == "" || strings.ContainsAny(host, `\^$*+?()[]{}|`) {
+		return "", false
+	}
+	return host, true
+}
+
+// environmentVariablePrefixes are the default prefixes of the environment variables returned by GetDrupalEnvironmentVariables
+var environmentVariablePrefixes = []string{"DRUPAL_", "DRUSH_", "PANTHEON_", "PLATFORM_", "AH_", "LANDO_", "DDEV_"}
+
+// GetDrupalEnvironmentVariables gets the environment variables visible to drupal whose names start with one of prefixes.
+// If no prefixes are given then DRUPAL_, DRUSH_, PANTHEON_, PLATFORM_, AH_, LANDO_ and DDEV_ are used.
+// Variables are read from $_SERVER as drupal is bootstrapped by drush, so they may differ from those seen by the web server.
+func (s Site) GetDrupalEnvironmentVariables(prefixes ...string) (map[string]string, error) {
+	if len(prefixes) == 0 {
+		prefixes = environmentVariablePrefixes
+	}
+
+	var server map[string]string
+	err := s.phpEval("print json_encode((object) array_filter($_SERVER, 'is_string'));", &server)
+	if err != nil {
+		return nil, err
+	}
+
+	variables := map[string]string{}
+	for name, value := range server {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(name, prefix) {
+				variables[name] = value
+				break
+			}
+		}
+	}
+	return variables, nil
+}
+
+// GetEnvironmentVariable gets a single environment variable visible to drupal, with any name.
+// Returns an error if the variable is not set.
+func (s Site) GetEnvironmentVariable(name string) (string, error) {
+	var value *string
+	err := s.phpEval("$value = getenv("+phpString(name)+"); print json_encode($value === FALSE ? NULL : $value);", &value)
+	if err != nil {
+		return "", err
+	}
+	if value == nil {
+		return "", errors.Newf("Environment variable %v is not set", name)
+	}
+	return *value, nil
+}
@@ -0,0 +1,14 @@
+package drupal
+
+import "github.com/phayes/errors"
+
+// Errors returned by Site methods.
+// These are usually wrapped with more detail, so use errors.IsA() to check for them.
+var (
+	ErrConfigSyncNotSet = errors.New("Drupal config sync directory not set")
+	ErrEntityNotFound   = errors.New("Drupal entity not found")
+	ErrInvalidState     = errors.New("Invalid moderation state")
+	ErrModuleNotEnabled = errors.New("Drupal module not enabled")
+	ErrSiteUUIDMismatch = errors.New("Drupal site UUID mismatch")
+	ErrUserNotFound     = errors.New("Drupal user not found")
+)
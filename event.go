@@ -0,0 +1,38 @@
+package drupal
+
+// EventSubscriber is a service that subscribes to events dispatched by drupal's event dispatcher
+type EventSubscriber struct {
+	ServiceID string              `json:"service_id"` // Empty if the service ID could not be determined
+	Class     string              `json:"class"`
+	Events    []EventSubscription `json:"events"`
+}
+
+// EventSubscription is a single method of an EventSubscriber that listens to an event
+type EventSubscription struct {
+	Event    string `json:"event"`
+	Method   string `json:"method"`
+	Priority int    `json:"priority"`
+}
+
+// GetEventSubscribers gets the subscribers listening to an event (eg "kernel.request"), with each subscriber's subscriptions to that event.
+// An empty eventName gets the subscribers to all events, with each subscriber's subscriptions grouped together.
+func (s Site) GetEventSubscribers(eventName string) ([]EventSubscriber, error) {
+	phpCode := "$dispatcher = \\Drupal::service('event_dispatcher'); " +
+		"$eventName = " + phpString(eventName) + "; " +
+		"$listeners = $eventName === '' ? $dispatcher->getListeners() : array($eventName => $dispatcher->getListeners($eventName)); " +
+		"$subscribers = array(); " +
+		"foreach ($listeners as $event => $eventListeners) { foreach ($eventListeners as $listener) { " +
+		"if (!is_array($listener) || !is_object($listener[0])) { continue; } " +
+		"$class = get_class($listener[0]); " +
+		"if (!isset($subscribers[$class])) { $subscribers[$class] = array('service_id' => isset($listener[0]->_serviceId) ? $listener[0]->_serviceId : '', 'class' => $class, 'events' => array()); } " +
+		"$subscribers[$class]['events'][] = array('event' => $event, 'method' => $listener[1], 'priority' => (int) $dispatcher->getListenerPriority($event, $listener)); " +
+		"} } " +
+		"print json_encode(array_values($subscribers));"
+
+	var subscribers []EventSubscriber
+	err := s.phpEval(phpCode, &subscribers)
+	if err != nil {
+		return nil, err
+	}
+	return subscribers, nil
+}
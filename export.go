@@ -0,0 +1,90 @@
+package drupal
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/phayes/errors"
+)
+
+// csvExportBatchSize is the number of entities loaded per drush invocation by ExportContentToCSV, to limit PHP memory usage
+const csvExportBatchSize = 100
+
+// ExportContentToCSV writes the given fields of every entity of a bundle as CSV rows to w.
+// The first row contains the field labels. Field values are formatted as strings, with multiple values separated by commas.
+// Returns an error if no fields are given or any of the fields does not exist on the bundle.
+func (s Site) ExportContentToCSV(entityType, bundle string, fields []string, w io.Writer) error {
+	if len(fields) == 0 {
+		return errors.New("No fields given for CSV export")
+	}
+
+	phpFields, err := phpValue(fields)
+	if err != nil {
+		return err
+	}
+
+	// Get field labels, and the IDs of all entities in the bundle
+	phpCode := "$definitions = \\Drupal::service('entity_field.manager')->getFieldDefinitions(" + phpString(entityType) + ", " + phpString(bundle) + "); " +
+		"$labels = array(); foreach (" + phpFields + " as $field) { $labels[] = isset($definitions[$field]) ? (string) $definitions[$field]->getLabel() : NULL; } " +
+		"$bundleKey = \\Drupal::entityTypeManager()->getDefinition(" + phpString(entityType) + ")->getKey('bundle'); " +
+		"$query = \\Drupal::entityQuery(" + phpString(entityType) + ")->accessCheck(FALSE)->sort(\\Drupal::entityTypeManager()->getDefinition(" + phpString(entityType) + ")->getKey('id')); " +
+		"if ($bundleKey) { $query->condition($bundleKey, " + phpString(bundle) + "); } " +
+		"print json_encode(array('labels' => $labels, 'ids' => array_map('intval', array_values($query->execute()))));"
+
+	var export struct {
+		Labels []*string `json:"labels"`
+		IDs    []int     `json:"ids"`
+	}
+	err = s.phpEval(phpCode, &export)
+	if err != nil {
+		return err
+	}
+
+	header := []string{}
+	for i, label := range export.Labels {
+		if label == nil {
+			return errors.Newf("Field %v does not exist on %v %v", fields[i], entityType, bundle)
+		}
+		header = append(header, *label)
+	}
+
+	writer := csv.NewWriter(w)
+	err = writer.Write(header)
+	if err != nil {
+		return errors.Wraps(err, "Error writing CSV export")
+	}
+
+	for start := 0; start < len(export.IDs); start += csvExportBatchSize {
+		end := start + csvExportBatchSize
+		if end > len(export.IDs) {
+			end = len(export.IDs)
+		}
+		phpIDs, err := phpValue(export.IDs[start:end])
+		if err != nil {
+			return err
+		}
+
+		phpCode := "$rows = array(); " +
+			"foreach (\\Drupal::entityTypeManager()->getStorage(" + phpString(entityType) + ")->loadMultiple(" + phpIDs + ") as $entity) { " +
+			"$row = array(); foreach (" + phpFields + " as $field) { $row[] = $entity->get($field)->getString(); } $rows[] = $row; " +
+			"} " +
+			"print json_encode($rows);"
+
+		var rows [][]string
+		err = s.phpEval(phpCode, &rows)
+		if err != nil {
+			return err
+		}
+		err = writer.WriteAll(rows)
+		if err != nil {
+			return errors.Wraps(err, "Error writing CSV export")
+		}
+	}
+
+	writer.Flush()
+	err = writer.Error()
+	if err != nil {
+		return errors.Wraps(err, "Error writing CSV export")
+	}
+	return nil
+}
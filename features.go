@@ -0,0 +1,48 @@
+package drupal
+
+// FeatureStatus is the status of a feature package provided by the features module
+type FeatureStatus struct {
+	Name    string   `json:"name"`    // Machine name of the feature
+	Status  string   `json:"status"`  // State of the feature's config (eg "Default", "Changed")
+	Modules []string `json:"modules"` // Modules the feature depends on
+}
+
+// GetFeaturesStatus gets the status of all feature packages.
+// The features.manager service is used rather than "drush features:status", which only reports the current bundle and export settings,
+// and "drush features:list-packages", which does not report package dependencies.
+// Packages are assigned with features_assigner first, as the drush commands do. This only changes the in-memory package list, nothing is saved.
+// Returns ErrModuleNotEnabled if the features module is not enabled.
+func (s Site) GetFeaturesStatus() ([]FeatureStatus, error) {
+	err := s.requireModule("features")
+	if err != nil {
+		return nil, err
+	}
+
+	phpCode := "\\Drupal::service('features_assigner')->assignConfigPackages(); " +
+		"$manager = \\Drupal::service('features.manager'); " +
+		"$features = array(); " +
+		"foreach ($manager->getPackages() as $package) { " +
+		"$features[] = array('name' => $package->getMachineName(), 'status' => (string) $manager->stateLabel($package->getState()), 'modules' => array_values($package->getDependencies())); " +
+		"} " +
+		"print json_encode($features);"
+
+	var features []FeatureStatus
+	err = s.phpEval(phpCode, &features)
+	if err != nil {
+		return nil, err
+	}
+	return features, nil
+}
+
+// RevertFeature reverts a feature's config to the state defined in the feature module, using "drush features-import".
+// features-import is the command name in both drush 8 and drush 9+, where "features-revert" (fr) is only an alias.
+// Returns ErrModuleNotEnabled if the features module is not enabled.
+func (s Site) RevertFeature(name string) (DrushMessages, error) {
+	err := s.requireModule("features")
+	if err != nil {
+		return nil, err
+	}
+
+	_, messages, errs := s.Drush("features-import", name)
+	return messages, errs
+}
@@ -0,0 +1,76 @@
+package drupal
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/phayes/errors"
+)
+
+// GetRSSFeed fetches the RSS feed produced by a views feed display over HTTP, returning the raw XML.
+// The feed URL is built from the site URI reported by "drush status", so the site must be reachable at that URI.
+func (s Site) GetRSSFeed(viewID, displayID string) (string, error) {
+	err := s.requireModule("views")
+	if err != nil {
+		return "", err
+	}
+
+	phpCode := "$view = \\Drupal\\views\\Views::getView(" + phpString(viewID) + "); " +
+		"if (!$view || !$view->setDisplay(" + phpString(displayID) + ") || $view->getDisplay()->getPluginId() != 'feed') { print json_encode(NULL); return; } " +
+		"print json_encode($view->getDisplay()->getPath());"
+
+	var path *string
+	err = s.phpEval(phpCode, &path)
+	if err != nil {
+		return "", err
+	}
+	if path == nil {
+		return "", errors.Newf("Feed display %v of view %v not found", displayID, viewID)
+	}
+	return s.fetchPath(*path)
+}
+
+// GetDefaultRSSFeed fetches the site's front page RSS feed at /rss.xml over HTTP, returning the raw XML
+func (s Site) GetDefaultRSSFeed() (string, error) {
+	return s.fetchPath("rss.xml")
+}
+
+// siteURL gets the URL of a path relative to the site URI reported by "drush status"
+func (s Site) siteURL(path string) (string, error) {
+	status, err := s.GetStatus()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(status.URI, "/") + "/" + strings.TrimLeft(path, "/"), nil
+}
+
+// httpTimeout is how long requests made to a site over HTTP may take, including reading the response body
+const httpTimeout = 30 * time.Second
+
+// httpClient is used for all requests made to a site over HTTP, so a site that hangs does not block forever
+var httpClient = &http.Client{Timeout: httpTimeout}
+
+// fetchPath fetches a path relative to the site URI over HTTP, returning the response body
+func (s Site) fetchPath(path string) (string, error) {
+	url, err := s.siteURL(path)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return "", errors.Wrapf(err, "Error fetching %v", url)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.Wrapf(err, "Error reading %v", url)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Newf("Error fetching %v: %v", url, resp.Status)
+	}
+	return string(body), nil
+}
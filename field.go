@@ -0,0 +1,84 @@
+package drupal
+
+import (
+	"github.com/phayes/errors"
+)
+
+// FieldStorageDefinition is the storage definition of a field, as defined in a field.storage.* config object
+type FieldStorageDefinition struct {
+	Type        string                 `json:"type"`
+	Label       string                 `json:"label"` // Field storage has no human-readable label, so this is the field's machine name
+	Module      string                 `json:"module"`
+	Cardinality int                    `json:"cardinality"` // Number of values the field can hold, or -1 for unlimited
+	Settings    map[string]interface{} `json:"settings"`
+	Indexes     map[string]interface{} `json:"indexes"`
+}
+
+// phpFieldStorage is php code defining a $fieldStorage closure that formats field.storage.* config data as a FieldStorageDefinition
+const phpFieldStorage = "$fieldStorage = function ($data) { return array('type' => $data['type'], 'label' => $data['field_name'], 'module' => $data['module'], 'cardinality' => (int) $data['cardinality'], 'settings' => (object) $data['settings'], 'indexes' => (object) $data['indexes']); }; "
+
+// GetFieldStorageDefinitions gets the storage definitions of all configurable fields on an entity type, keyed by field name
+func (s Site) GetFieldStorageDefinitions(entityType string) (map[string]*FieldStorageDefinition, error) {
+	phpCode := phpFieldStorage + phpListConfig("field.storage."+entityType+".") + "$definitions = array(); foreach ($configs as $data) { $definitions[$data['field_name']] = $fieldStorage($data); } print json_encode((object) $definitions);"
+
+	var definitions map[string]*FieldStorageDefinition
+	err := s.phpEval(phpCode, &definitions)
+	if err != nil {
+		return nil, err
+	}
+	return definitions, nil
+}
+
+// GetFieldStorageDefinition gets the storage definition of a single configurable field on an entity type
+func (s Site) GetFieldStorageDefinition(entityType, fieldName string) (*FieldStorageDefinition, error) {
+	phpCode := phpFieldStorage + "$config = \\Drupal::config(" + phpString("field.storage."+entityType+"."+fieldName) + "); print json_encode($config->isNew() ? NULL : $fieldStorage($config->getRawData()));"
+
+	var definition *FieldStorageDefinition
+	err := s.phpEval(phpCode, &definition)
+	if err != nil {
+		return nil, err
+	}
+	if definition == nil {
+		return nil, errors.Newf("Field storage for %v %v not found", entityType, fieldName)
+	}
+	return definition, nil
+}
+
+// FieldEncryptionInfo describes a field encrypted by the field_encrypt module on a bundle
+type FieldEncryptionInfo struct {
+	EntityType        string   `json:"entity_type"`
+	Bundle            string   `json:"bundle"`
+	Field             string   `json:"field"`
+	EncryptionProfile string   `json:"encryption_profile"`
+	Properties        []string `json:"properties"` // The encrypted field properties (eg "value", "summary")
+}
+
+// GetFieldEncryptionStatus gets every field encrypted by the field_encrypt module, with one entry for each bundle the field is attached to.
+// Returns ErrModuleNotEnabled if the field_encrypt module is not enabled.
+func (s Site) GetFieldEncryptionStatus() ([]FieldEncryptionInfo, error) {
+	err := s.requireModule("field_encrypt")
+	if err != nil {
+		return nil, err
+	}
+
+	// field_encrypt stores it's settings as third party settings on the field storage
+	phpCode := "$factory = \\Drupal::configFactory(); " +
+		"$fields = array(); " +
+		"foreach ($factory->loadMultiple($factory->listAll('field.storage.')) as $storage) { " +
+		"$settings = $storage->get('third_party_settings.field_encrypt'); " +
+		"if (empty($settings['encrypt'])) { continue; } " +
+		"$entityType = $storage->get('entity_type'); $fieldName = $storage->get('field_name'); " +
+		"foreach ($factory->loadMultiple($factory->listAll('field.field.' . $entityType . '.')) as $field) { " +
+		"if ($field->get('field_name') != $fieldName) { continue; } " +
+		"$fields[] = array('entity_type' => $entityType, 'bundle' => $field->get('bundle'), 'field' => $fieldName, 'encryption_profile' => isset($settings['encryption_profile']) ? (string) $settings['encryption_profile'] : '', 'properties' => isset($settings['properties']) ? array_values(array_filter($settings['properties'])) : array()); " +
+		"} " +
+		"} " +
+		"print json_encode($fields);"
+
+	var fields []FieldEncryptionInfo
+	err = s.phpEval(phpCode, &fields)
+	if err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
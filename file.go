@@ -0,0 +1,54 @@
+package drupal
+
+import (
+	"strconv"
+
+	"github.com/phayes/errors"
+)
+
+// FileUsage records that a module is using a file on behalf of an entity
+type FileUsage struct {
+	FID        int    `json:"fid"`
+	Module     string `json:"module"`
+	EntityType string `json:"entity_type"`
+	EntityID   int    `json:"entity_id"`
+	Count      int    `json:"count"`
+}
+
+// GetFileUsage gets the usage of a managed file
+func (s Site) GetFileUsage(fid int) ([]FileUsage, error) {
+	phpCode := phpLoadEntity("file", fid) +
+		"$usages = array(); " +
+		"foreach (\\Drupal::service('file.usage')->listUsage($entity) as $module => $types) { foreach ($types as $type => $ids) { foreach ($ids as $id => $count) { " +
+		"$usages[] = array('fid' => (int) $entity->id(), 'module' => $module, 'entity_type' => $type, 'entity_id' => (int) $id, 'count' => (int) $count); " +
+		"} } } " +
+		"print json_encode($usages);"
+
+	var usages *[]FileUsage
+	err := s.phpEval(phpCode, &usages)
+	if err != nil {
+		return nil, err
+	}
+	if usages == nil {
+		return nil, errors.Wrapf(ErrEntityNotFound, "Could not load file %v", fid)
+	}
+	return *usages, nil
+}
+
+// GetOrphanedFiles gets the IDs of managed files that are not used by anything, up to limit files. A limit of 0 gets all orphaned files.
+func (s Site) GetOrphanedFiles(limit int) ([]int, error) {
+	phpCode := "$query = \\Drupal::database()->select('file_managed', 'f')->fields('f', array('fid')); " +
+		"$query->leftJoin('file_usage', 'u', 'f.fid = u.fid'); " +
+		"$query->isNull('u.fid')->orderBy('f.fid'); "
+	if limit > 0 {
+		phpCode += "$query->range(0, " + strconv.Itoa(limit) + "); "
+	}
+	phpCode += "print json_encode(array_map('intval', $query->execute()->fetchCol()));"
+
+	var fids []int
+	err := s.phpEval(phpCode, &fids)
+	if err != nil {
+		return nil, err
+	}
+	return fids, nil
+}
@@ -0,0 +1,105 @@
+package drupal
+
+import (
+	"strconv"
+
+	"github.com/phayes/errors"
+)
+
+// Flag is a flag defined by the flag module
+type Flag struct {
+	ID         string   `json:"id"`
+	Label      string   `json:"label"`
+	EntityType string   `json:"entity_type"`
+	Bundles    []string `json:"bundles"` // Bundles that can be flagged, or empty for all bundles
+	Global     bool     `json:"global"`  // Whether the flag is shared by all users, rather than per user
+}
+
+// GetFlags gets all flags.
+// Returns ErrModuleNotEnabled if the flag module is not enabled.
+func (s Site) GetFlags() ([]Flag, error) {
+	err := s.requireModule("flag")
+	if err != nil {
+		return nil, err
+	}
+
+	phpCode := phpListConfig("flag.flag.") +
+		"$flags = array(); foreach ($configs as $data) { $flags[] = array('id' => $data['id'], 'label' => $data['label'], 'entity_type' => $data['entity_type'], 'bundles' => array_values((array) $data['bundles']), 'global' => (bool) $data['global']); } " +
+		"print json_encode($flags);"
+
+	var flags []Flag
+	err = s.phpEval(phpCode, &flags)
+	if err != nil {
+		return nil, err
+	}
+	return flags, nil
+}
+
+// phpLoadFlagging returns php code that loads $flag and $entity, printing a flagResult and returning if either does not exist
+func phpLoadFlagging(flagID string, entityType string, entityID int) string {
+	return "$flagService = \\Drupal::service('flag'); " +
+		"$flag = $flagService->getFlagById(" + phpString(flagID) + "); if (!$flag) { print json_encode(array('missing' => 'flag')); return; } " +
+		"$entity = \\Drupal::entityTypeManager()->getStorage(" + phpString(entityType) + ")->load(" + strconv.Itoa(entityID) + "); if (!$entity) { print json_encode(array('missing' => 'entity')); return; } "
+}
+
+// flagResult is the result of a flag operation as printed by php
+type flagResult struct {
+	Missing string `json:"missing"` // "flag", "entity" or "user" if it could not be loaded
+	Count   int    `json:"count"`
+}
+
+// runFlagging runs php code that prints a flagResult, and converts missing flags, entities or users into errors
+func (s Site) runFlagging(phpCode string, flagID string, entityType string, entityID int, uid int) (int, error) {
+	err := s.requireModule("flag")
+	if err != nil {
+		return 0, err
+	}
+
+	var result flagResult
+	err = s.phpEval(phpCode, &result)
+	if err != nil {
+		return 0, err
+	}
+	if result.Missing == "flag" {
+		return 0, errors.Newf("Flag %v not found", flagID)
+	}
+	err = missingError(result.Missing, entityType, entityID, uid)
+	if err != nil {
+		return 0, err
+	}
+	return result.Count, nil
+}
+
+// FlagEntity flags an entity on behalf of a user.
+// Returns ErrModuleNotEnabled if the flag module is not enabled.
+func (s Site) FlagEntity(flagID string, entityType string, entityID int, uid int) error {
+	phpCode := phpLoadFlagging(flagID, entityType, entityID) +
+		"$account = \\Drupal::entityTypeManager()->getStorage('user')->load(" + strconv.Itoa(uid) + "); if (!$account) { print json_encode(array('missing' => 'user')); return; } " +
+		"if (!$flag->isFlagged($entity, $account)) { $flagService->flag($flag, $entity, $account); } " +
+		"print json_encode(array('missing' => ''));"
+
+	_, err := s.runFlagging(phpCode, flagID, entityType, entityID, uid)
+	return err
+}
+
+// UnflagEntity removes a user's flag from an entity.
+// Returns ErrModuleNotEnabled if the flag module is not enabled.
+func (s Site) UnflagEntity(flagID string, entityType string, entityID int, uid int) error {
+	phpCode := phpLoadFlagging(flagID, entityType, entityID) +
+		"$account = \\Drupal::entityTypeManager()->getStorage('user')->load(" + strconv.Itoa(uid) + "); if (!$account) { print json_encode(array('missing' => 'user')); return; } " +
+		"if ($flag->isFlagged($entity, $account)) { $flagService->unflag($flag, $entity, $account); } " +
+		"print json_encode(array('missing' => ''));"
+
+	_, err := s.runFlagging(phpCode, flagID, entityType, entityID, uid)
+	return err
+}
+
+// GetEntityFlagCount gets the number of times an entity has been flagged with a flag.
+// Returns ErrModuleNotEnabled if the flag module is not enabled.
+func (s Site) GetEntityFlagCount(flagID string, entityType string, entityID int) (int, error) {
+	phpCode := phpLoadFlagging(flagID, entityType, entityID) +
+		"$counts = \\Drupal::service('flag.count')->getEntityFlagCounts($entity); " +
+		"print json_encode(array('missing' => '', 'count' => isset($counts[" + phpString(flagID) + "]) ? (int) $counts[" + phpString(flagID) + "] : 0));"
+
+	return s.runFlagging(phpCode, flagID, entityType, entityID, 0)
+}
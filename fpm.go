@@ -0,0 +1,49 @@
+package drupal
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/phayes/errors"
+)
+
+// PHPFpmStatus is the status of a PHP-FPM pool, as reported by it's status page
+type PHPFpmStatus struct {
+	Pool                string `json:"pool"`
+	AcceptedConnections int64  `json:"accepted conn"`
+	ActiveProcesses     int    `json:"active processes"`
+	IdleProcesses       int    `json:"idle processes"`
+	MaxChildrenReached  int    `json:"max children reached"`
+	SlowRequests        int    `json:"slow requests"`
+}
+
+// GetPHPFpmStatus fetches the status of a PHP-FPM pool from it's status page (pm.status_path), such as "http://localhost/fpm-status".
+// The json query parameter is added to statusURL if it is not already present.
+func (s Site) GetPHPFpmStatus(statusURL string) (*PHPFpmStatus, error) {
+	parsed, err := url.Parse(statusURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Invalid PHP-FPM status URL %v", statusURL)
+	}
+	query := parsed.Query()
+	if _, ok := query["json"]; !ok {
+		query.Set("json", "")
+		parsed.RawQuery = query.Encode()
+	}
+
+	resp, err := httpClient.Get(parsed.String())
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error fetching PHP-FPM status from %v", statusURL)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Newf("Error fetching PHP-FPM status from %v: %v", statusURL, resp.Status)
+	}
+
+	var status PHPFpmStatus
+	err = json.NewDecoder(resp.Body).Decode(&status)
+	if err != nil {
+		return nil, errors.Wraps(err, "Error decoding PHP-FPM status")
+	}
+	return &status, nil
+}
@@ -0,0 +1,107 @@
+package drupal
+
+import (
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/phayes/errors"
+)
+
+// GetFrontController gets the path of the site's front controller, index.php in the drupal root, checking that it is readable
+func (s Site) GetFrontController() (string, error) {
+	status, err := s.GetStatus()
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(status.Root, "index.php")
+	file, err := os.Open(path)
+	if err != nil {
+		return "", errors.Wraps(err, "Error reading front controller")
+	}
+	file.Close()
+	return path, nil
+}
+
+// GetRewriteRules gets the RewriteCond and RewriteRule directives from the .htaccess file in the drupal root.
+// If there is no .htaccess file, the try_files directives of the nginx server configuration for the site URI's host are returned instead.
+func (s Site) GetRewriteRules() ([]string, error) {
+	status, err := s.GetStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	directives := []string{"rewritecond", "rewriterule"}
+	content, err := ioutil.ReadFile(filepath.Join(status.Root, ".htaccess"))
+	if os.IsNotExist(err) {
+		uri, err := url.Parse(status.URI)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Invalid site URI %v", status.URI)
+		}
+		config, err := s.GetNginxServerConfig(uri.Hostname())
+		if err != nil {
+			return nil, err
+		}
+		content = []byte(config)
+		directives = []string{"try_files"}
+	} else if err != nil {
+		return nil, errors.Wraps(err, "Error reading .htaccess")
+	}
+
+	rules := []string{}
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		for _, directive := range directives {
+			if strings.ToLower(fields[0]) == directive {
+				rules = append(rules, line)
+			}
+		}
+	}
+	return rules, nil
+}
+
+// ValidateFrontController checks that index.php loads drupal's autoload.php, and that the composer autoloader it requires exists
+func (s Site) ValidateFrontController() error {
+	status, err := s.GetStatus()
+	if err != nil {
+		return err
+	}
+	return validateFrontController(status.Root)
+}
+
+// autoloadRequire matches the path required relative to __DIR__ in drupal's autoload.php
+var autoloadRequire = regexp.MustCompile(`require(?:_once)?\s*\(?\s*__DIR__\s*\.\s*['"]([^'"]+)['"]`)
+
+// validateFrontController checks the front controller and autoloader in a drupal root directory
+func validateFrontController(root string) error {
+	index, err := ioutil.ReadFile(filepath.Join(root, "index.php"))
+	if err != nil {
+		return errors.Wraps(err, "Error reading front controller")
+	}
+	if !strings.Contains(string(index), "autoload.php") {
+		return errors.New("Front controller index.php does not load autoload.php")
+	}
+
+	autoload, err := ioutil.ReadFile(filepath.Join(root, "autoload.php"))
+	if err != nil {
+		return errors.Wraps(err, "Error reading autoload.php")
+	}
+	match := autoloadRequire.FindStringSubmatch(string(autoload))
+	if match == nil {
+		return errors.New("autoload.php does not require a composer autoloader")
+	}
+
+	_, err = os.Stat(filepath.Join(root, match[1]))
+	if err != nil {
+		return errors.Wrapf(err, "Composer autoloader %v required by autoload.php not found", match[1])
+	}
+	return nil
+}
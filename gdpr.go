@@ -0,0 +1,27 @@
+package drupal
+
+// GDPRStatus reports which GDPR compliance features are provided by the site's modules
+type GDPRStatus struct {
+	GDPRModuleEnabled    bool `json:"gdpr"`        // The gdpr module is enabled
+	AnonymizationEnabled bool `json:"anonymize"`   // The gdpr_dump module is enabled, so database dumps are anonymized
+	ConsentModuleEnabled bool `json:"consent"`     // The consent or gdpr_consent module is enabled, or eu_cookie_compliance is enabled with it's popup turned on
+	DataExportEnabled    bool `json:"data_export"` // The gdpr_tasks module is enabled, so users can request an export of their data
+}
+
+// GetGDPRStatus checks which GDPR compliance modules are enabled and configured
+func (s Site) GetGDPRStatus() (*GDPRStatus, error) {
+	phpCode := "$modules = \\Drupal::moduleHandler(); " +
+		"$cookies = $modules->moduleExists('eu_cookie_compliance') && \\Drupal::config('eu_cookie_compliance.settings')->get('popup_enabled'); " +
+		"print json_encode(array(" +
+		"'gdpr' => $modules->moduleExists('gdpr'), " +
+		"'anonymize' => $modules->moduleExists('gdpr_dump'), " +
+		"'consent' => $modules->moduleExists('consent') || $modules->moduleExists('gdpr_consent') || (bool) $cookies, " +
+		"'data_export' => $modules->moduleExists('gdpr_tasks')));"
+
+	var status GDPRStatus
+	err := s.phpEval(phpCode, &status)
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
@@ -0,0 +1,47 @@
+package drupal
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/phayes/errors"
+)
+
+// FlushImageStyle flushes all generated derivative images for an image style
+func (s Site) FlushImageStyle(styleName string) error {
+	_, _, errs := s.Drush("image-flush", styleName)
+	return errs
+}
+
+// FlushAllImageStyles flushes all generated derivative images for all image styles
+func (s Site) FlushAllImageStyles() error {
+	_, _, errs := s.Drush("image-flush", "--all")
+	return errs
+}
+
+// GetImageStyleStatus counts the derivative images that have been generated for an image style in the public files directory
+func (s Site) GetImageStyleStatus(styleName string) (int, error) {
+	var directory string
+	err := s.phpEval("print json_encode((string) \\Drupal::service('file_system')->realpath('public://styles/' . "+phpString(styleName)+"));", &directory)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	if directory == "" {
+		return count, nil
+	}
+	err = filepath.Walk(directory, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			count++
+		}
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return 0, errors.Wrapf(err, "Error counting derivative images for image style %v", styleName)
+	}
+	return count, nil
+}
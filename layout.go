@@ -0,0 +1,50 @@
+package drupal
+
+import (
+	"github.com/phayes/errors"
+)
+
+// LayoutSection is a Layout Builder section
+type LayoutSection struct {
+	LayoutID   string                 `json:"layout_id"`
+	Components []LayoutComponent      `json:"components"`
+	Layout     map[string]interface{} `json:"layout_settings"`
+}
+
+// LayoutComponent is a block placed in a region of a Layout Builder section
+type LayoutComponent struct {
+	UUID          string                 `json:"uuid"`
+	Region        string                 `json:"region"`
+	Configuration map[string]interface{} `json:"configuration"`
+}
+
+// GetLayoutBuilderSections gets the Layout Builder sections that override the layout of an entity.
+// An entity without an overridden layout has no sections.
+// Returns ErrModuleNotEnabled if the layout_builder module is not enabled.
+func (s Site) GetLayoutBuilderSections(entityType string, id int) ([]LayoutSection, error) {
+	err := s.requireModule("layout_builder")
+	if err != nil {
+		return nil, err
+	}
+
+	phpCode := phpLoadEntity(entityType, id) +
+		"$sections = array(); " +
+		"if ($entity->hasField('layout_builder__layout')) { " +
+		"foreach ($entity->get('layout_builder__layout')->getSections() as $section) { " +
+		"$section = $section->toArray(); " +
+		"$components = array(); foreach ($section['components'] as $component) { $components[] = array('uuid' => $component['uuid'], 'region' => $component['region'], 'configuration' => (object) $component['configuration']); } " +
+		"$sections[] = array('layout_id' => $section['layout_id'], 'components' => $components, 'layout_settings' => (object) $section['layout_settings']); " +
+		"} " +
+		"} " +
+		"print json_encode($sections);"
+
+	var sections *[]LayoutSection
+	err = s.phpEval(phpCode, &sections)
+	if err != nil {
+		return nil, err
+	}
+	if sections == nil {
+		return nil, errors.Wrapf(ErrEntityNotFound, "Could not load %v %v", entityType, id)
+	}
+	return *sections, nil
+}
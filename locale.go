@@ -0,0 +1,49 @@
+package drupal
+
+// TranslationStatus is the interface translation coverage of a language
+type TranslationStatus struct {
+	Interface         string  `json:"interface"` // Language code
+	TotalStrings      int     `json:"total"`
+	TranslatedStrings int     `json:"translated"`
+	Coverage          float64 `json:"-"` // Percentage of strings that are translated
+}
+
+// GetTranslationStatus checks for updated translations using "drush locale-check", then gets the interface translation coverage of a language.
+// Returns ErrModuleNotEnabled if the locale module is not enabled.
+func (s Site) GetTranslationStatus(langCode string) (*TranslationStatus, error) {
+	err := s.requireModule("locale")
+	if err != nil {
+		return nil, err
+	}
+
+	_, _, errs := s.Drush("locale-check", "--langcode="+langCode)
+	if errs != nil {
+		return nil, errs
+	}
+
+	phpCode := "$storage = \\Drupal::service('locale.storage'); " +
+		"$translated = $storage->countTranslations(); " +
+		"print json_encode(array('interface' => " + phpString(langCode) + ", 'total' => (int) $storage->countStrings(), 'translated' => isset($translated[" + phpString(langCode) + "]) ? (int) $translated[" + phpString(langCode) + "] : 0));"
+
+	var status TranslationStatus
+	err = s.phpEval(phpCode, &status)
+	if err != nil {
+		return nil, err
+	}
+	if status.TotalStrings > 0 {
+		status.Coverage = float64(status.TranslatedStrings) / float64(status.TotalStrings) * 100
+	}
+	return &status, nil
+}
+
+// ImportTranslations imports a gettext .po file into a language using "drush locale-import".
+// Returns ErrModuleNotEnabled if the locale module is not enabled.
+func (s Site) ImportTranslations(langCode, poFilePath string) (DrushMessages, error) {
+	err := s.requireModule("locale")
+	if err != nil {
+		return nil, err
+	}
+
+	_, messages, errs := s.Drush("locale-import", langCode, poFilePath)
+	return messages, errs
+}
@@ -0,0 +1,100 @@
+package drupal
+
+import (
+	"strconv"
+	"time"
+)
+
+// EmailMessage is an outgoing email waiting in the mail queue
+type EmailMessage struct {
+	ID        int // The queue item ID
+	Recipient string
+	Subject   string
+	Module    string
+	Key       string
+	Queued    time.Time
+}
+
+// GetEmailQueue gets up to limit emails waiting in the mail queue, oldest first. A limit of 0 gets all queued emails.
+// Neither mailsystem nor swiftmailer queue mail, so this reads the "queue_mail" queue used by the queue_mail module.
+// Returns ErrModuleNotEnabled if the queue_mail module is not enabled.
+func (s Site) GetEmailQueue(limit int) ([]EmailMessage, error) {
+	err := s.requireModule("queue_mail")
+	if err != nil {
+		return nil, err
+	}
+
+	phpCode := "$query = \\Drupal::database()->select('queue', 'q')->fields('q', array('item_id', 'data', 'created'))->condition('name', 'queue_mail')->orderBy('created')->orderBy('item_id'); "
+	if limit > 0 {
+		phpCode += "$query->range(0, " + strconv.Itoa(limit) + "); "
+	}
+	phpCode += "$messages = array(); " +
+		"foreach ($query->execute() as $row) { " +
+		"$message = unserialize($row->data); " +
+		"$messages[] = array('id' => (int) $row->item_id, 'to' => isset($message['to']) ? (string) $message['to'] : '', 'subject' => isset($message['subject']) ? (string) $message['subject'] : '', 'module' => isset($message['module']) ? $message['module'] : '', 'key' => isset($message['key']) ? $message['key'] : '', 'created' => (int) $row->created); " +
+		"} " +
+		"print json_encode($messages);"
+
+	var messagesJSON []struct {
+		ID      int    `json:"id"`
+		To      string `json:"to"`
+		Subject string `json:"subject"`
+		Module  string `json:"module"`
+		Key     string `json:"key"`
+		Created int64  `json:"created"`
+	}
+	err = s.phpEval(phpCode, &messagesJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := []EmailMessage{}
+	for _, message := range messagesJSON {
+		messages = append(messages, EmailMessage{
+			ID:        message.ID,
+			Recipient: message.To,
+			Subject:   message.Subject,
+			Module:    message.Module,
+			Key:       message.Key,
+			Queued:    time.Unix(message.Created, 0),
+		})
+	}
+	return messages, nil
+}
+
+// GetEmailQueueCount gets the number of emails waiting in the mail queue.
+// Returns ErrModuleNotEnabled if the queue_mail module is not enabled.
+func (s Site) GetEmailQueueCount() (int, error) {
+	err := s.requireModule("queue_mail")
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	err = s.phpEval("print json_encode((int) \\Drupal::queue('queue_mail')->numberOfItems());", &count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// FlushEmailQueue sends all emails waiting in the mail queue using "drush queue-run", returning the number of emails processed.
+// Emails that fail to send are left in the queue to be retried.
+// Returns ErrModuleNotEnabled if the queue_mail module is not enabled.
+func (s Site) FlushEmailQueue() (int, error) {
+	before, err := s.GetEmailQueueCount()
+	if err != nil {
+		return 0, err
+	}
+
+	_, _, errs := s.Drush("queue-run", "queue_mail")
+	if errs != nil {
+		return 0, errs
+	}
+
+	after, err := s.GetEmailQueueCount()
+	if err != nil {
+		return 0, err
+	}
+	return before - after, nil
+}
@@ -0,0 +1,107 @@
+package drupal
+
+import (
+	"strings"
+
+	"github.com/phayes/errors"
+)
+
+// MenuLink is a single link in a menu
+type MenuLink struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	URL      string `json:"url"`
+	MenuName string `json:"menu_name"`
+	ParentID string `json:"parent_id"` // Empty for links at the top level of the menu
+	Weight   int    `json:"weight"`
+	Enabled  bool   `json:"enabled"`
+	External bool   `json:"external"`
+}
+
+// GetMenuLinks gets all links in a menu (eg "main") as a flat list. An empty menuName gets the links in all menus.
+func (s Site) GetMenuLinks(menuName string) ([]MenuLink, error) {
+	properties := "array()"
+	if menuName != "" {
+		properties = "array('menu_name' => " + phpString(menuName) + ")"
+	}
+
+	phpCode := "$manager = \\Drupal::service('plugin.manager.menu.link'); " +
+		"$links = array(); " +
+		"foreach (\\Drupal::service('menu.tree_storage')->loadByProperties(" + properties + ") as $id => $definition) { " +
+		"$link = $manager->createInstance($id); $url = $link->getUrlObject(); " +
+		"$links[] = array('id' => $id, 'title' => (string) $link->getTitle(), 'url' => $url->toString(), 'menu_name' => $link->getMenuName(), 'parent_id' => (string) $link->getParent(), 'weight' => (int) $link->getWeight(), 'enabled' => (bool) $link->isEnabled(), 'external' => $url->isExternal()); " +
+		"} " +
+		"print json_encode($links);"
+
+	var links []MenuLink
+	err := s.phpEval(phpCode, &links)
+	if err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// GetMenuLinkByURL finds the first menu link, in any menu, that links to a URL (eg "/node/1" or "https://example.com")
+func (s Site) GetMenuLinkByURL(path string) (*MenuLink, error) {
+	links, err := s.GetMenuLinks("")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, link := range links {
+		if link.URL == path {
+			return &link, nil
+		}
+	}
+	return nil, errors.Newf("No menu link found for %v", path)
+}
+
+// AccessibilityIssue is an accessibility problem found by static analysis
+type AccessibilityIssue struct {
+	ElementType string // The kind of element with the issue, eg "menu_link"
+	ElementID   string
+	Issue       string
+	Severity    string // "error" or "warning"
+}
+
+// GetMenuAccessibilityIssues checks the enabled links in a menu for empty titles, sibling links with the same title,
+// and links to the same URL. Links are not rendered, so only the menu definition is checked.
+func (s Site) GetMenuAccessibilityIssues(menuName string) ([]AccessibilityIssue, error) {
+	links, err := s.GetMenuLinks(menuName)
+	if err != nil {
+		return nil, err
+	}
+	return menuAccessibilityIssues(links), nil
+}
+
+// menuAccessibilityIssues checks menu links for accessibility issues, in the order of the links
+func menuAccessibilityIssues(links []MenuLink) []AccessibilityIssue {
+	issues := []AccessibilityIssue{}
+	titles := map[string]string{} // parent and title to the first link ID
+	urls := map[string]string{}   // URL to the first link ID
+
+	for _, link := range links {
+		if !link.Enabled {
+			continue
+		}
+
+		title := strings.TrimSpace(link.Title)
+		if title == "" {
+			issues = append(issues, AccessibilityIssue{ElementType: "menu_link", ElementID: link.ID, Issue: "Link has no title", Severity: "error"})
+		} else {
+			key := link.ParentID + "\x00" + strings.ToLower(title)
+			if first, ok := titles[key]; ok {
+				issues = append(issues, AccessibilityIssue{ElementType: "menu_link", ElementID: link.ID, Issue: "Link has the same title as sibling link " + first, Severity: "warning"})
+			} else {
+				titles[key] = link.ID
+			}
+		}
+
+		if first, ok := urls[link.URL]; ok {
+			issues = append(issues, AccessibilityIssue{ElementType: "menu_link", ElementID: link.ID, Issue: "Link has the same URL as link " + first, Severity: "warning"})
+		} else {
+			urls[link.URL] = link.ID
+		}
+	}
+	return issues
+}
@@ -0,0 +1,65 @@
+package drupal
+
+import (
+	"github.com/phayes/errors"
+)
+
+// GetModerationState gets the current moderation state of an entity (eg "draft", "published").
+// Returns ErrModuleNotEnabled if the content_moderation module is not enabled.
+func (s Site) GetModerationState(entityType string, id int) (string, error) {
+	err := s.requireModule("content_moderation")
+	if err != nil {
+		return "", err
+	}
+
+	phpCode := phpLoadEntity(entityType, id) +
+		"print json_encode($entity->hasField('moderation_state') ? (string) $entity->get('moderation_state')->value : '');"
+
+	var state *string
+	err = s.phpEval(phpCode, &state)
+	if err != nil {
+		return "", err
+	}
+	if state == nil {
+		return "", errors.Wrapf(ErrEntityNotFound, "Could not load %v %v", entityType, id)
+	}
+	if *state == "" {
+		return "", errors.Newf("%v %v is not moderated", entityType, id)
+	}
+	return *state, nil
+}
+
+// SetModerationState moves an entity to a new moderation state, saving a new revision.
+// Returns ErrInvalidState if the state does not exist in the entity's workflow,
+// or ErrModuleNotEnabled if the content_moderation module is not enabled.
+func (s Site) SetModerationState(entityType string, id int, state string) error {
+	err := s.requireModule("content_moderation")
+	if err != nil {
+		return err
+	}
+
+	phpCode := phpLoadEntity(entityType, id) +
+		"$workflow = \\Drupal::service('content_moderation.moderation_information')->getWorkflowForEntity($entity); " +
+		"if (!$workflow) { print json_encode('unmoderated'); return; } " +
+		"if (!$workflow->getTypePlugin()->hasState(" + phpString(state) + ")) { print json_encode('invalid'); return; } " +
+		"$entity->set('moderation_state', " + phpString(state) + "); " +
+		"if ($entity->getEntityType()->isRevisionable()) { $entity->setNewRevision(TRUE); } " +
+		"$entity->save(); " +
+		"print json_encode('ok');"
+
+	var result *string
+	err = s.phpEval(phpCode, &result)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		return errors.Wrapf(ErrEntityNotFound, "Could not load %v %v", entityType, id)
+	}
+	switch *result {
+	case "unmoderated":
+		return errors.Newf("%v %v is not moderated", entityType, id)
+	case "invalid":
+		return errors.Wrapf(ErrInvalidState, "%v is not a state in the workflow for %v %v", state, entityType, id)
+	}
+	return nil
+}
@@ -0,0 +1,367 @@
+package drupal
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/phayes/errors"
+)
+
+// moduleEnabled checks if a module is installed and enabled on the site
+func (s Site) moduleEnabled(module string) (bool, error) {
+	var enabled bool
+	err := s.phpEval("print json_encode(\\Drupal::moduleHandler()->moduleExists("+phpString(module)+"));", &enabled)
+	if err != nil {
+		return false, err
+	}
+	return enabled, nil
+}
+
+// requireModule returns ErrModuleNotEnabled if the module is not enabled on the site
+func (s Site) requireModule(module string) error {
+	enabled, err := s.moduleEnabled(module)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return errors.Wrapf(ErrModuleNotEnabled, "%v module is not enabled", module)
+	}
+	return nil
+}
+
+// Module is a drupal module, as reported by "drush pm-list"
+type Module struct {
+	Name        string // Machine name
+	DisplayName string // Human readable name
+	Package     string
+	Version     string
+	Status      string
+}
+
+// GetModules gets all enabled modules, sorted by machine name
+func (s Site) GetModules() ([]Module, error) {
+	output, _, errs := s.Drush("pm-list", "--type=module", "--status=enabled", "--format=json")
+	if errs != nil {
+		return nil, errs
+	}
+
+	// Drush 8 reports the display name as "name", later versions use "display_name"
+	var list map[string]struct {
+		Name        string `json:"name"`
+		DisplayName string `json:"display_name"`
+		Package     string `json:"package"`
+		Version     string `json:"version"`
+		Status      string `json:"status"`
+	}
+	err := json.Unmarshal([]byte(output), &list)
+	if err != nil {
+		return nil, errors.Wraps(err, "Error reading drupal modules")
+	}
+
+	modules := []Module{}
+	for name, info := range list {
+		module := Module{Name: name, DisplayName: info.DisplayName, Package: info.Package, Version: info.Version, Status: info.Status}
+		if module.DisplayName == "" {
+			module.DisplayName = info.Name
+		}
+		modules = append(modules, module)
+	}
+	sort.Slice(modules, func(i, j int) bool { return modules[i].Name < modules[j].Name })
+
+	return modules, nil
+}
+
+// GetInstalledModulesByPackage gets all enabled modules grouped by package.
+// Modules within each package are sorted by machine name. Use GetPackages() to get the package names in sorted order.
+func (s Site) GetInstalledModulesByPackage() (map[string][]Module, error) {
+	modules, err := s.GetModules()
+	if err != nil {
+		return nil, err
+	}
+
+	packages := map[string][]Module{}
+	for _, module := range modules {
+		packages[module.Package] = append(packages[module.Package], module)
+	}
+	return packages, nil
+}
+
+// GetPackages gets the sorted names of all packages that have enabled modules
+func (s Site) GetPackages() ([]string, error) {
+	packages, err := s.GetInstalledModulesByPackage()
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for name := range packages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// HookImplementation is a single implementation of a hook by a module
+type HookImplementation struct {
+	Hook     string
+	Module   string
+	Function string
+}
+
+// GetHookImplementations gets all the modules that implement a hook.
+// hookName should not include the "hook_" prefix (eg "form_alter").
+func (s Site) GetHookImplementations(hookName string) ([]HookImplementation, error) {
+	hookName = strings.TrimPrefix(hookName, "hook_")
+
+	var modules []string
+	err := s.phpEval("print json_encode(\\Drupal::moduleHandler()->getImplementations("+phpString(hookName)+"));", &modules)
+	if err != nil {
+		return nil, err
+	}
+
+	implementations := []HookImplementation{}
+	for _, module := range modules {
+		implementations = append(implementations, HookImplementation{Hook: hookName, Module: module, Function: module + "_" + hookName})
+	}
+	return implementations, nil
+}
+
+// GetModuleHooks gets the names of the hooks implemented by a module, without the "hook_" prefix.
+// Hooks are discovered from the functions defined by the module once all module files are loaded,
+// so hooks implemented in files that are only included on demand will not be listed.
+// Functions belonging to another enabled module with a longer name (eg "foo_bar_*" for "foo") are skipped,
+// and the rest are confirmed with the module handler so helper functions (eg "node_load") are not listed.
+// Returns ErrModuleNotEnabled if the module is not enabled.
+func (s Site) GetModuleHooks(moduleName string) ([]string, error) {
+	err := s.requireModule(moduleName)
+	if err != nil {
+		return nil, err
+	}
+
+	phpCode := "\\Drupal::moduleHandler()->loadAll(); $prefix = " + phpString(moduleName+"_") + "; $functions = array(); " +
+		"foreach (get_defined_functions()['user'] as $function) { if (strpos($function, $prefix) === 0) { $functions[] = $function; } } " +
+		"print json_encode(array('functions' => $functions, 'modules' => array_keys(\\Drupal::moduleHandler()->getModuleList())));"
+
+	var defined struct {
+		Functions []string `json:"functions"`
+		Modules   []string `json:"modules"`
+	}
+	err = s.phpEval(phpCode, &defined)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := moduleHookCandidates(moduleName, defined.Functions, defined.Modules)
+	if len(candidates) == 0 {
+		return []string{}, nil
+	}
+	phpCandidates, err := phpValue(candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	phpCode = "$hooks = array(); foreach (" + phpCandidates + " as $hook) { if (\\Drupal::moduleHandler()->implementsHook(" + phpString(moduleName) + ", $hook)) { $hooks[] = $hook; } } print json_encode($hooks);"
+
+	hooks := []string{}
+	err = s.phpEval(phpCode, &hooks)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(hooks)
+	return hooks, nil
+}
+
+// moduleHookCandidates gets the possible hook names from the functions starting with a module's name.
+// Functions that start with the name of a longer enabled module (eg "foo_bar_" when the module is "foo") belong to that module and are skipped.
+func moduleHookCandidates(moduleName string, functions []string, modules []string) []string {
+	prefix := moduleName + "_"
+	longer := []string{}
+	for _, module := range modules {
+		if strings.HasPrefix(module, prefix) {
+			longer = append(longer, module+"_")
+		}
+	}
+
+	candidates := []string{}
+	for _, function := range functions {
+		if !strings.HasPrefix(function, prefix) {
+			continue
+		}
+		owned := true
+		for _, other := range longer {
+			if strings.HasPrefix(function, other) {
+				owned = false
+				break
+			}
+		}
+		if owned && len(function) > len(prefix) {
+			candidates = append(candidates, function[len(prefix):])
+		}
+	}
+	sort.Strings(candidates)
+	return candidates
+}
+
+// ModuleInfo contains the metadata of a module, from it's .info.yml file
+type ModuleInfo struct {
+	Name              string   `json:"name"`
+	Description       string   `json:"description"`
+	Package           string   `json:"package"`
+	Version           string   `json:"version"`
+	CoreCompatibility string   `json:"core"`         // Either the "core" or "core_version_requirement" key
+	Dependencies      []string `json:"dependencies"` // As written in the .info.yml file, (eg "drupal:node (>=8.5)")
+	ConflictsWith     []string `json:"conflicts"`
+	Path              string   `json:"path"`
+}
+
+// phpModuleList is php code that sets $modules to all available modules, keyed by machine name.
+// The extension.list.module service was added in Drupal 8.6.
+const phpModuleList = "$modules = \\Drupal::hasService('extension.list.module') ? \\Drupal::service('extension.list.module')->getList() : system_rebuild_module_data(); "
+
+// phpModuleInfo is php code defining a $moduleInfo closure that formats a module extension as a ModuleInfo
+const phpModuleInfo = "$moduleInfo = function ($module) { $info = $module->info; return array(" +
+	"'name' => $info['name'], " +
+	"'description' => isset($info['description']) ? $info['description'] : '', " +
+	"'package' => isset($info['package']) ? $info['package'] : '', " +
+	"'version' => isset($info['version']) ? $info['version'] : '', " +
+	"'core' => isset($info['core_version_requirement']) ? $info['core_version_requirement'] : (isset($info['core']) ? $info['core'] : ''), " +
+	"'dependencies' => isset($info['dependencies']) ? array_values($info['dependencies']) : array(), " +
+	"'conflicts' => isset($info['conflicts']) ? array_values($info['conflicts']) : array(), " +
+	"'path' => $module->getPath()); }; "
+
+// GetModuleInfo gets the metadata of an available module.
+// The module does not need to be enabled.
+func (s Site) GetModuleInfo(name string) (*ModuleInfo, error) {
+	phpCode := phpModuleList + phpModuleInfo + "print json_encode(isset($modules[" + phpString(name) + "]) ? $moduleInfo($modules[" + phpString(name) + "]) : NULL);"
+
+	var info *ModuleInfo
+	err := s.phpEval(phpCode, &info)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, errors.Newf("Module %v not found", name)
+	}
+	return info, nil
+}
+
+// GetModuleDependencyGraph gets the machine names of the modules each available module directly depends on, keyed by module machine name
+func (s Site) GetModuleDependencyGraph() (map[string][]string, error) {
+	phpCode := phpModuleList + phpModuleInfo + "print json_encode((object) array_map($moduleInfo, $modules));"
+
+	var modules map[string]ModuleInfo
+	err := s.phpEval(phpCode, &modules)
+	if err != nil {
+		return nil, err
+	}
+
+	graph := map[string][]string{}
+	for name, info := range modules {
+		graph[name] = []string{}
+		for _, dependency := range info.Dependencies {
+			graph[name] = append(graph[name], dependencyName(dependency))
+		}
+	}
+	return graph, nil
+}
+
+// dependencyName returns the module machine name from a .info.yml dependency, which may be namespaced by project and include a version constraint
+func dependencyName(dependency string) string {
+	if i := strings.Index(dependency, "("); i != -1 {
+		dependency = dependency[:i]
+	}
+	if i := strings.Index(dependency, ":"); i != -1 {
+		dependency = dependency[i+1:]
+	}
+	return strings.TrimSpace(dependency)
+}
+
+// GetModuleWeights gets the weight of every enabled module from the core.extension config, keyed by module name.
+// Modules with lower weights have their hooks invoked first.
+func (s Site) GetModuleWeights() (map[string]int, error) {
+	var extension struct {
+		Module map[string]int `json:"module"`
+	}
+	err := s.getConfig("core.extension", &extension)
+	if err != nil {
+		return nil, err
+	}
+	return extension.Module, nil
+}
+
+// GetModuleWeight gets the weight of an enabled module.
+// Returns ErrModuleNotEnabled if the module is not enabled.
+func (s Site) GetModuleWeight(moduleName string) (int, error) {
+	weights, err := s.GetModuleWeights()
+	if err != nil {
+		return 0, err
+	}
+
+	weight, ok := weights[moduleName]
+	if !ok {
+		return 0, errors.Wrapf(ErrModuleNotEnabled, "%v module is not enabled", moduleName)
+	}
+	return weight, nil
+}
+
+// SetModuleWeight sets the weight of an enabled module, changing the order in which it's hooks are invoked.
+// Returns ErrModuleNotEnabled if the module is not enabled.
+func (s Site) SetModuleWeight(moduleName string, weight int) error {
+	err := s.requireModule(moduleName)
+	if err != nil {
+		return err
+	}
+	return s.phpEval("module_set_weight("+phpString(moduleName)+", "+strconv.Itoa(weight)+");", nil)
+}
+
+// GetEntityTypeDependencies gets the modules an entity type depends on: the module that provides it,
+// and the module providing it's bundle entity type if that is different.
+func (s Site) GetEntityTypeDependencies(entityType string) ([]string, error) {
+	phpCode := "$manager = \\Drupal::entityTypeManager(); " +
+		"$definition = $manager->getDefinition(" + phpString(entityType) + "); " +
+		"$modules = array($definition->getProvider()); " +
+		"if ($definition->getBundleEntityType()) { $modules[] = $manager->getDefinition($definition->getBundleEntityType())->getProvider(); } " +
+		"print json_encode(array_values(array_unique($modules)));"
+
+	var modules []string
+	err := s.phpEval(phpCode, &modules)
+	if err != nil {
+		return nil, err
+	}
+	return modules, nil
+}
+
+// GetModuleDependents gets the enabled modules that directly depend on a module, sorted by name.
+// A module cannot be uninstalled while it has dependents.
+func (s Site) GetModuleDependents(moduleName string) ([]string, error) {
+	graph, err := s.GetModuleDependencyGraph()
+	if err != nil {
+		return nil, err
+	}
+	enabled, err := s.GetModuleWeights()
+	if err != nil {
+		return nil, err
+	}
+
+	return moduleDependents(graph, enabled, moduleName), nil
+}
+
+// moduleDependents finds the modules in enabled whose dependencies in graph include module
+func moduleDependents(graph map[string][]string, enabled map[string]int, module string) []string {
+	dependents := []string{}
+	for name, dependencies := range graph {
+		if _, ok := enabled[name]; !ok {
+			continue
+		}
+		for _, dependency := range dependencies {
+			if dependency == module {
+				dependents = append(dependents, name)
+				break
+			}
+		}
+	}
+	sort.Strings(dependents)
+	return dependents
+}
@@ -0,0 +1,84 @@
+package drupal
+
+import (
+	"strings"
+
+	"github.com/phayes/errors"
+)
+
+// NewRelicConfig is the configuration of the New Relic PHP agent
+type NewRelicConfig struct {
+	Enabled           bool
+	AppName           string
+	LicenseKeySet     bool
+	TransactionTracer bool
+}
+
+// GetNewRelicConfig gets the New Relic PHP agent configuration.
+// The enabled, app_name, license_key and transaction_tracer keys under new_relic in the monitoring.settings config are read first, if that config exists.
+// The newrelic.* php.ini settings of the php command line executable are then layered on top, since they are what the agent actually uses.
+// The exception is AppName, which is taken from monitoring.settings when set there because the site applies it at runtime with newrelic_set_appname().
+// If neither configures the agent Enabled will be false.
+func (s Site) GetNewRelicConfig() (*NewRelicConfig, error) {
+	config := NewRelicConfig{}
+
+	exists, err := s.configExists("monitoring.settings")
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		var settings struct {
+			NewRelic struct {
+				Enabled           bool   `json:"enabled"`
+				AppName           string `json:"app_name"`
+				LicenseKey        string `json:"license_key"`
+				TransactionTracer bool   `json:"transaction_tracer"`
+			} `json:"new_relic"`
+		}
+		err = s.getConfig("monitoring.settings", &settings)
+		if err != nil {
+			return nil, err
+		}
+		config.Enabled = settings.NewRelic.Enabled
+		config.AppName = settings.NewRelic.AppName
+		config.LicenseKeySet = settings.NewRelic.LicenseKey != ""
+		config.TransactionTracer = settings.NewRelic.TransactionTracer
+	}
+
+	values, err := getPHPINIValues("newrelic.enabled", "newrelic.appname", "newrelic.license", "newrelic.transaction_tracer.enabled")
+	if err != nil {
+		return nil, err
+	}
+	if values["newrelic.enabled"] != "" {
+		config.Enabled = phpINIBool(values["newrelic.enabled"])
+	}
+	if values["newrelic.appname"] != "" && config.AppName == "" {
+		config.AppName = values["newrelic.appname"]
+	}
+	if values["newrelic.license"] != "" {
+		config.LicenseKeySet = true
+	}
+	if values["newrelic.transaction_tracer.enabled"] != "" {
+		config.TransactionTracer = phpINIBool(values["newrelic.transaction_tracer.enabled"])
+	}
+	return &config, nil
+}
+
+// SetNewRelicAppName sets the New Relic application name for the site in the new_relic.app_name key of the monitoring.settings config.
+// This is the value GetNewRelicConfig returns as AppName.
+func (s Site) SetNewRelicAppName(name string) error {
+	if name == "" || strings.ContainsAny(name, "\n") {
+		return errors.Newf("Invalid New Relic application name %v", name)
+	}
+	return s.setConfig("monitoring.settings", "new_relic.app_name", name)
+}
+
+// phpINIBool checks if an ini_get value is a php.ini boolean that is on
+func phpINIBool(value string) bool {
+	switch strings.ToLower(value) {
+	case "", "0", "off", "no", "false", "none":
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,48 @@
+package drupal
+
+import (
+	"strconv"
+
+	"github.com/phayes/errors"
+)
+
+// GetNodesByType gets the IDs of nodes of a content type, ordered by node ID.
+// status is 0 for unpublished nodes, 1 for published nodes or -1 for any node. A limit of 0 gets all nodes after offset.
+func (s Site) GetNodesByType(contentType string, status int, limit, offset int) ([]int, error) {
+	phpCode := "$query = \\Drupal::entityQuery('node')->accessCheck(FALSE)->condition('type', " + phpString(contentType) + ")->sort('nid'); "
+	switch status {
+	case 0, 1:
+		phpCode += "$query->condition('status', " + strconv.Itoa(status) + "); "
+	case -1:
+	default:
+		return nil, errors.Newf("Invalid node status %v", status)
+	}
+	if limit > 0 {
+		phpCode += "$query->range(" + strconv.Itoa(offset) + ", " + strconv.Itoa(limit) + "); "
+	} else if offset > 0 {
+		phpCode += "$query->range(" + strconv.Itoa(offset) + ", PHP_INT_MAX); "
+	}
+	phpCode += "print json_encode(array_map('intval', array_values($query->execute())));"
+
+	var nids []int
+	err := s.phpEval(phpCode, &nids)
+	if err != nil {
+		return nil, err
+	}
+	return nids, nil
+}
+
+// GetNodeIDsByField gets the IDs of entities of a type where a field has a value, ordered by ID.
+// fieldName may reference a property of the field, such as "field_tags.target_id", and defaults to the field's main property.
+func (s Site) GetNodeIDsByField(entityType, fieldName, fieldValue string) ([]int, error) {
+	phpCode := "$query = \\Drupal::entityQuery(" + phpString(entityType) + ")->accessCheck(FALSE)->condition(" + phpString(fieldName) + ", " + phpString(fieldValue) + "); " +
+		"$query->sort(\\Drupal::entityTypeManager()->getDefinition(" + phpString(entityType) + ")->getKey('id')); " +
+		"print json_encode(array_map('intval', array_values($query->execute())));"
+
+	var ids []int
+	err := s.phpEval(phpCode, &ids)
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
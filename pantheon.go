@@ -0,0 +1,56 @@
+package drupal
+
+import (
+	"os"
+
+	"github.com/phayes/errors"
+)
+
+// PantheonInfo describes the Pantheon environment a site is running in
+type PantheonInfo struct {
+	Environment string // dev, test, live or a multidev name
+	SiteUUID    string
+	BindingUUID string
+	Region      string // Only set if PANTHEON_REGION is present
+	Upstream    string // Only set if PANTHEON_UPSTREAM is present
+}
+
+// GetPantheonInfo gets the Pantheon environment the site is running in from the PANTHEON_* environment variables.
+// Returns an error if the site is not running on Pantheon.
+func (s Site) GetPantheonInfo() (*PantheonInfo, error) {
+	if !s.IsPantheonSite() {
+		return nil, errors.Newf("%v is not running on Pantheon", s)
+	}
+
+	info := PantheonInfo{
+		Environment: os.Getenv("PANTHEON_ENVIRONMENT"),
+		SiteUUID:    os.Getenv("PANTHEON_SITE"),
+		BindingUUID: os.Getenv("PANTHEON_BINDING"),
+		Region:      os.Getenv("PANTHEON_REGION"),
+		Upstream:    os.Getenv("PANTHEON_UPSTREAM"),
+	}
+	return &info, nil
+}
+
+// IsPantheonSite checks if the site is running on Pantheon, by checking if the PANTHEON_ENVIRONMENT environment variable is set
+func (s Site) IsPantheonSite() bool {
+	return os.Getenv("PANTHEON_ENVIRONMENT") != ""
+}
+
+// GetPantheonEnvironmentURLs gets the default pantheonsite.io URLs of the dev, test and live environments, keyed by environment.
+// Custom domains are not included.
+func (s Site) GetPantheonEnvironmentURLs() (map[string]string, error) {
+	if !s.IsPantheonSite() {
+		return nil, errors.Newf("%v is not running on Pantheon", s)
+	}
+	name := os.Getenv("PANTHEON_SITE_NAME")
+	if name == "" {
+		return nil, errors.New("PANTHEON_SITE_NAME is not set")
+	}
+
+	urls := map[string]string{}
+	for _, environment := range []string{"dev", "test", "live"} {
+		urls[environment] = "https://" + environment + "-" + name + ".pantheonsite.io"
+	}
+	return urls, nil
+}
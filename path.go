@@ -0,0 +1,114 @@
+package drupal
+
+import (
+	"github.com/phayes/errors"
+)
+
+// phpAliasManager is php code that sets $aliasManager to the path alias manager service, which was renamed in Drupal 8.8
+const phpAliasManager = "$aliasManager = \\Drupal::hasService('path_alias.manager') ? \\Drupal::service('path_alias.manager') : \\Drupal::service('path.alias_manager'); "
+
+// GetNodeByPath loads the node at a path alias (eg "/about-us") and returns an array of it's field values
+func (s Site) GetNodeByPath(path string) (*map[string]interface{}, error) {
+	phpCode := phpAliasManager +
+		"$internal = $aliasManager->getPathByAlias(" + phpString(path) + "); " +
+		"$node = preg_match('#^/node/(\\d+)$#', $internal, $matches) ? \\Drupal::entityTypeManager()->getStorage('node')->load($matches[1]) : NULL; " +
+		"print json_encode($node ? $node->toArray() : NULL);"
+
+	var node *map[string]interface{}
+	err := s.phpEval(phpCode, &node)
+	if err != nil {
+		return nil, err
+	}
+	if node == nil {
+		return nil, errors.Wrapf(ErrEntityNotFound, "No node found at path %v", path)
+	}
+	return node, nil
+}
+
+// GetPathAlias gets the alias for an internal path (eg "/node/1").
+// If the path has no alias, the internal path is returned unchanged.
+func (s Site) GetPathAlias(internalPath string) (string, error) {
+	var alias string
+	err := s.phpEval(phpAliasManager+"print json_encode($aliasManager->getAliasByPath("+phpString(internalPath)+"));", &alias)
+	if err != nil {
+		return "", err
+	}
+	return alias, nil
+}
+
+// CreatePathAlias creates an alias for an internal path. Both should start with a "/".
+func (s Site) CreatePathAlias(path, alias string) error {
+	phpCode := "if (\\Drupal::entityTypeManager()->hasDefinition('path_alias')) { " +
+		"\\Drupal::entityTypeManager()->getStorage('path_alias')->create(array('path' => " + phpString(path) + ", 'alias' => " + phpString(alias) + "))->save(); " +
+		"} else { " +
+		"\\Drupal::service('path.alias_storage')->save(" + phpString(path) + ", " + phpString(alias) + "); " +
+		"}"
+	return s.phpEval(phpCode, nil)
+}
+
+// DeletePathAlias deletes a path alias
+func (s Site) DeletePathAlias(alias string) error {
+	phpCode := "if (\\Drupal::entityTypeManager()->hasDefinition('path_alias')) { " +
+		"$storage = \\Drupal::entityTypeManager()->getStorage('path_alias'); $storage->delete($storage->loadByProperties(array('alias' => " + phpString(alias) + "))); " +
+		"} else { " +
+		"\\Drupal::service('path.alias_storage')->delete(array('alias' => " + phpString(alias) + ")); " +
+		"}"
+	return s.phpEval(phpCode, nil)
+}
+
+// BreadcrumbLink is a single link in a breadcrumb trail
+type BreadcrumbLink struct {
+	Text string `json:"text"`
+	URL  string `json:"url"`
+}
+
+// GetBreadcrumb builds the breadcrumb trail for a path (eg "/node/1" or an alias), as it would be displayed on that page
+func (s Site) GetBreadcrumb(path string) ([]BreadcrumbLink, error) {
+	phpCode := "$request = \\Symfony\\Component\\HttpFoundation\\Request::create(" + phpString(path) + "); " +
+		"\\Drupal::requestStack()->push($request); " +
+		"$internal = \\Drupal::service('path_processor_manager')->processInbound($request->getPathInfo(), $request); " +
+		"$request->attributes->add(\\Drupal::service('router.no_access_checks')->match($internal)); " +
+		"$links = array(); " +
+		"foreach (\\Drupal::service('breadcrumb')->build(\\Drupal\\Core\\Routing\\RouteMatch::createFromRequest($request))->getLinks() as $link) { " +
+		"$links[] = array('text' => strip_tags((string) $link->getText()), 'url' => $link->getUrl()->toString()); " +
+		"} " +
+		"print json_encode($links);"
+
+	var links []BreadcrumbLink
+	err := s.phpEval(phpCode, &links)
+	if err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// GetAdminPaths gets the path patterns of all admin routes (eg "/admin/config", "/node/{node}/edit"), sorted
+func (s Site) GetAdminPaths() ([]string, error) {
+	phpCode := "$adminContext = \\Drupal::service('router.admin_context'); " +
+		"$paths = array(); " +
+		"foreach (\\Drupal::service('router.route_provider')->getAllRoutes() as $route) { if ($adminContext->isAdminRoute($route)) { $paths[] = $route->getPath(); } } " +
+		"$paths = array_values(array_unique($paths)); sort($paths); " +
+		"print json_encode($paths);"
+
+	var paths []string
+	err := s.phpEval(phpCode, &paths)
+	if err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
+// IsAdminPath checks if a path (eg "/node/1/edit" or an alias) is served by an admin route
+func (s Site) IsAdminPath(path string) (bool, error) {
+	phpCode := "$request = \\Symfony\\Component\\HttpFoundation\\Request::create(" + phpString(path) + "); " +
+		"$internal = \\Drupal::service('path_processor_manager')->processInbound($request->getPathInfo(), $request); " +
+		"$match = \\Drupal::service('router.no_access_checks')->match($internal); " +
+		"print json_encode(\\Drupal::service('router.admin_context')->isAdminRoute($match[\\Drupal\\Core\\Routing\\RouteObjectInterface::ROUTE_OBJECT]));"
+
+	var admin bool
+	err := s.phpEval(phpCode, &admin)
+	if err != nil {
+		return false, err
+	}
+	return admin, nil
+}
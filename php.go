@@ -0,0 +1,59 @@
+package drupal
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/phayes/errors"
+)
+
+// phpString returns str as a single-quoted PHP string literal
+func phpString(str string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(str) + "'"
+}
+
+// phpValue returns value as a PHP expression by round-tripping it through json_decode
+func phpValue(value interface{}) (string, error) {
+	out, err := json.Marshal(value)
+	if err != nil {
+		return "", errors.Wraps(err, "Could not encode value for php")
+	}
+	return "json_decode(" + phpString(string(out)) + ", TRUE)", nil
+}
+
+// phpEval runs php code in the context of the bootstrapped drupal site using "drush php-eval".
+// The code should print it's result using json_encode, which is then decoded into v.
+// If v is nil the output is discarded.
+func (s Site) phpEval(phpCode string, v interface{}) error {
+	output, _, errs := s.Drush("php-eval", phpCode)
+	if errs != nil {
+		return errs
+	}
+	if v == nil {
+		return nil
+	}
+
+	err := json.Unmarshal([]byte(output), v)
+	if err != nil {
+		return errors.Wraps(err, "Could not decode php output")
+	}
+	return nil
+}
+
+// MemoryInfo is the memory used by php
+type MemoryInfo struct {
+	CurrentBytes     int64 `json:"current"`
+	PeakBytes        int64 `json:"peak"`
+	RealCurrentBytes int64 `json:"real_current"` // Memory allocated from the system, including unused memory in php's allocator
+	RealPeakBytes    int64 `json:"real_peak"`
+}
+
+// GetMemoryUsage gets the memory used by php after bootstrapping drupal with "drush php-eval"
+func (s Site) GetMemoryUsage() (*MemoryInfo, error) {
+	var memory MemoryInfo
+	err := s.phpEval("print json_encode(array('current' => memory_get_usage(), 'peak' => memory_get_peak_usage(), 'real_current' => memory_get_usage(TRUE), 'real_peak' => memory_get_peak_usage(TRUE)));", &memory)
+	if err != nil {
+		return nil, err
+	}
+	return &memory, nil
+}
@@ -0,0 +1,154 @@
+package drupal
+
+import (
+	"encoding/json"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/phayes/errors"
+)
+
+// PHPINISettings are the php.ini settings relevant to running drupal
+type PHPINISettings struct {
+	MemoryLimit       string
+	MaxExecutionTime  int
+	UploadMaxFilesize string
+	PostMaxSize       string
+	MaxInputTime      int
+	DisplayErrors     bool
+	ErrorReporting    int
+}
+
+// GetPHPINISettings gets php.ini settings from the php command line executable.
+// The command line may use a different php.ini than the web server, and always has a max_execution_time of 0.
+func (s Site) GetPHPINISettings() (*PHPINISettings, error) {
+	values, err := getPHPINIValues("memory_limit", "max_execution_time", "upload_max_filesize", "post_max_size", "max_input_time", "display_errors", "error_reporting")
+	if err != nil {
+		return nil, err
+	}
+
+	settings := PHPINISettings{
+		MemoryLimit:       values["memory_limit"],
+		UploadMaxFilesize: values["upload_max_filesize"],
+		PostMaxSize:       values["post_max_size"],
+	}
+	settings.MaxExecutionTime, _ = strconv.Atoi(values["max_execution_time"])
+	settings.MaxInputTime, _ = strconv.Atoi(values["max_input_time"])
+	settings.ErrorReporting, _ = strconv.Atoi(values["error_reporting"])
+	settings.DisplayErrors = phpINIBool(values["display_errors"])
+	return &settings, nil
+}
+
+// ValidatePHPForDrupal checks php.ini settings against drupal's recommendations, returning a description of each suboptimal setting.
+// An empty list means no problems were found.
+func (s Site) ValidatePHPForDrupal() []string {
+	settings, err := s.GetPHPINISettings()
+	if err != nil {
+		return []string{"Could not read php.ini settings: " + err.Error()}
+	}
+
+	problems := []string{}
+	memoryLimit := phpINIBytes(settings.MemoryLimit)
+	if memoryLimit != -1 && memoryLimit < 128*1024*1024 {
+		problems = append(problems, "memory_limit is "+settings.MemoryLimit+", drupal recommends at least 128M")
+	}
+	postMaxSize := phpINIBytes(settings.PostMaxSize)
+	if postMaxSize > 0 && postMaxSize < phpINIBytes(settings.UploadMaxFilesize) {
+		problems = append(problems, "post_max_size ("+settings.PostMaxSize+") is smaller than upload_max_filesize ("+settings.UploadMaxFilesize+"), limiting uploads to post_max_size")
+	}
+	if settings.DisplayErrors {
+		problems = append(problems, "display_errors is on, which should be off on production sites")
+	}
+	return problems
+}
+
+// getPHPINIValues gets the values of php.ini settings from the php command line executable, using ini_get
+func getPHPINIValues(keys ...string) (map[string]string, error) {
+	phpCode := "$values = array(); foreach (array("
+	for i, key := range keys {
+		if i > 0 {
+			phpCode += ", "
+		}
+		phpCode += phpString(key)
+	}
+	phpCode += ") as $key) { $values[$key] = (string) ini_get($key); } echo json_encode((object) $values);"
+
+	out, err := exec.Command("php", "-r", phpCode).Output()
+	if err != nil {
+		return nil, errors.Wraps(err, "Error fetching php.ini settings")
+	}
+
+	var values map[string]string
+	err = json.Unmarshal(out, &values)
+	if err != nil {
+		return nil, errors.Wraps(err, "Error fetching php.ini settings")
+	}
+	return values, nil
+}
+
+// phpINIBytes converts a php.ini size such as "128M" to a number of bytes, as php does. "-1" means unlimited.
+func phpINIBytes(size string) int64 {
+	size = strings.TrimSpace(size)
+	if size == "" {
+		return 0
+	}
+
+	multiplier := int64(1)
+	switch size[len(size)-1] {
+	case 'k', 'K':
+		multiplier = 1024
+	case 'm', 'M':
+		multiplier = 1024 * 1024
+	case 'g', 'G':
+		multiplier = 1024 * 1024 * 1024
+	}
+	if multiplier != 1 {
+		size = size[:len(size)-1]
+	}
+
+	value, _ := strconv.ParseInt(size, 10, 64)
+	return value * multiplier
+}
+
+// DrupalRequiredPHPExtensions are the php extensions drupal requires or strongly recommends
+var DrupalRequiredPHPExtensions = []string{"curl", "date", "dom", "filter", "gd", "hash", "json", "mbstring", "pcre", "pdo", "session", "SimpleXML", "SPL", "tokenizer", "xml"}
+
+// GetPHPExtensions gets the extensions loaded by the php command line executable, sorted by name
+func (s Site) GetPHPExtensions() ([]string, error) {
+	out, err := exec.Command("php", "-r", "echo json_encode(get_loaded_extensions());").Output()
+	if err != nil {
+		return nil, errors.Wraps(err, "Error fetching php extensions")
+	}
+
+	var extensions []string
+	err = json.Unmarshal(out, &extensions)
+	if err != nil {
+		return nil, errors.Wraps(err, "Error fetching php extensions")
+	}
+	sort.Strings(extensions)
+	return extensions, nil
+}
+
+// ValidatePHPExtensions gets the extensions in required, such as DrupalRequiredPHPExtensions, that are not loaded by the php command line executable.
+// Extension names are compared case-insensitively. If the loaded extensions cannot be determined, all of required is returned.
+func (s Site) ValidatePHPExtensions(required []string) []string {
+	extensions, err := s.GetPHPExtensions()
+	if err != nil {
+		return required
+	}
+
+	loaded := map[string]bool{}
+	for _, extension := range extensions {
+		loaded[strings.ToLower(extension)] = true
+	}
+
+	missing := []string{}
+	for _, extension := range required {
+		if !loaded[strings.ToLower(extension)] {
+			missing = append(missing, extension)
+		}
+	}
+	return missing
+}
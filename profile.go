@@ -0,0 +1,111 @@
+package drupal
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/phayes/errors"
+)
+
+// getProfileInfo parses an install profile's .info.yml file and decodes it into v.
+// Returns an *os.PathError for which os.IsNotExist is true if the profile cannot be found.
+func (s Site) getProfileInfo(profileName string, v interface{}) error {
+	phpCode := "$path = drupal_get_path('profile', " + phpString(profileName) + "); " +
+		"print json_encode($path ? (object) \\Drupal::service('info_parser')->parse($path . '/' . " + phpString(profileName+".info.yml") + ") : NULL);"
+
+	var info *json.RawMessage
+	err := s.phpEval(phpCode, &info)
+	if err != nil {
+		return err
+	}
+	if info == nil {
+		return &os.PathError{Op: "find install profile", Path: profileName, Err: os.ErrNotExist}
+	}
+
+	err = json.Unmarshal(*info, v)
+	if err != nil {
+		return errors.Wrapf(err, "Error reading install profile %v", profileName)
+	}
+	return nil
+}
+
+// GetProfileModules gets the modules listed under "install" in an install profile's .info.yml file.
+// These are installed with the profile, but may later be uninstalled. Project namespaces and version constraints are removed.
+func (s Site) GetProfileModules(profileName string) ([]string, error) {
+	var info struct {
+		Install []string `json:"install"`
+	}
+	err := s.getProfileInfo(profileName, &info)
+	if err != nil {
+		return nil, err
+	}
+	return dependencyNames(info.Install), nil
+}
+
+// GetProfileDependencies gets the modules listed under "dependencies" in an install profile's .info.yml file.
+// These are required by the profile, and cannot be uninstalled. Project namespaces and version constraints are removed.
+func (s Site) GetProfileDependencies(profileName string) ([]string, error) {
+	var info struct {
+		Dependencies []string `json:"dependencies"`
+	}
+	err := s.getProfileInfo(profileName, &info)
+	if err != nil {
+		return nil, err
+	}
+	return dependencyNames(info.Dependencies), nil
+}
+
+// dependencyNames returns the module machine names of a list of .info.yml dependencies
+func dependencyNames(dependencies []string) []string {
+	names := []string{}
+	for _, dependency := range dependencies {
+		names = append(names, dependencyName(dependency))
+	}
+	return names
+}
+
+// ProfileInfo is the metadata of an install profile, from it's .info.yml file
+type ProfileInfo struct {
+	Name              string
+	Description       string
+	CoreCompatibility string   // The core_version_requirement, or core for older profiles (eg "8.x")
+	Dependencies      []string // Project namespaces and version constraints are removed
+	ExcludedModules   []string // From the excluded_modules key, which few profiles set
+	Themes            []string
+	DistributionName  string // Empty if the profile is not a distribution
+}
+
+// GetProfileInfo gets the metadata of an install profile from it's .info.yml file.
+// Returns an *os.PathError for which os.IsNotExist is true if the profile cannot be found.
+func (s Site) GetProfileInfo(profileName string) (*ProfileInfo, error) {
+	var info struct {
+		Name                   string   `json:"name"`
+		Description            string   `json:"description"`
+		Core                   string   `json:"core"`
+		CoreVersionRequirement string   `json:"core_version_requirement"`
+		Dependencies           []string `json:"dependencies"`
+		ExcludedModules        []string `json:"excluded_modules"`
+		Themes                 []string `json:"themes"`
+		Distribution           struct {
+			Name string `json:"name"`
+		} `json:"distribution"`
+	}
+	err := s.getProfileInfo(profileName, &info)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := ProfileInfo{
+		Name:              info.Name,
+		Description:       info.Description,
+		CoreCompatibility: info.CoreVersionRequirement,
+		Dependencies:      dependencyNames(info.Dependencies),
+		ExcludedModules:   info.ExcludedModules,
+		Themes:            info.Themes,
+		DistributionName:  info.Distribution.Name,
+	}
+	if profile.CoreCompatibility == "" {
+		profile.CoreCompatibility = info.Core
+	}
+	return &profile, nil
+}
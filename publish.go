@@ -0,0 +1,121 @@
+package drupal
+
+import (
+	"time"
+
+	"github.com/phayes/errors"
+)
+
+// PublishStatus is the published state of an entity
+type PublishStatus struct {
+	EntityType  string
+	EntityID    int
+	Published   bool
+	LastChanged time.Time // The zero time if the entity type does not track changes
+	OwnerUID    int       // 0 if the entity type has no owner
+}
+
+// GetEntityPublishStatus loads entities and gets their published state. IDs that do not exist are omitted.
+// Entities that cannot be published or unpublished are always reported as published.
+func (s Site) GetEntityPublishStatus(entityType string, ids []int) ([]PublishStatus, error) {
+	phpIDs, err := phpValue(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	phpCode := "$statuses = array(); " +
+		"foreach (\\Drupal::entityTypeManager()->getStorage(" + phpString(entityType) + ")->loadMultiple(" + phpIDs + ") as $entity) { " +
+		"$statuses[] = array(" +
+		"'id' => (int) $entity->id(), " +
+		"'published' => $entity instanceof \\Drupal\\Core\\Entity\\EntityPublishedInterface ? $entity->isPublished() : TRUE, " +
+		"'changed' => $entity instanceof \\Drupal\\Core\\Entity\\EntityChangedInterface ? (int) $entity->getChangedTime() : 0, " +
+		"'uid' => $entity instanceof \\Drupal\\user\\EntityOwnerInterface ? (int) $entity->getOwnerId() : 0); " +
+		"} " +
+		"print json_encode($statuses);"
+
+	var statusesJSON []struct {
+		ID        int   `json:"id"`
+		Published bool  `json:"published"`
+		Changed   int64 `json:"changed"`
+		UID       int   `json:"uid"`
+	}
+	err = s.phpEval(phpCode, &statusesJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := []PublishStatus{}
+	for _, status := range statusesJSON {
+		publishStatus := PublishStatus{EntityType: entityType, EntityID: status.ID, Published: status.Published, OwnerUID: status.UID}
+		if status.Changed != 0 {
+			publishStatus.LastChanged = time.Unix(status.Changed, 0)
+		}
+		statuses = append(statuses, publishStatus)
+	}
+	return statuses, nil
+}
+
+// PublishEntity publishes an entity
+func (s Site) PublishEntity(entityType string, id int) error {
+	return s.setPublished(entityType, []int{id}, true)
+}
+
+// UnpublishEntity unpublishes an entity
+func (s Site) UnpublishEntity(entityType string, id int) error {
+	return s.setPublished(entityType, []int{id}, false)
+}
+
+// PublishEntities publishes several entities of the same type.
+// If any entity cannot be loaded or published then none are changed.
+func (s Site) PublishEntities(entityType string, ids []int) error {
+	return s.setPublished(entityType, ids, true)
+}
+
+// UnpublishEntities unpublishes several entities of the same type.
+// If any entity cannot be loaded or unpublished then none are changed.
+func (s Site) UnpublishEntities(entityType string, ids []int) error {
+	return s.setPublished(entityType, ids, false)
+}
+
+// setPublished loads entities, sets their published state and saves them in a single database transaction.
+// Returns ErrEntityNotFound if any entity does not exist.
+func (s Site) setPublished(entityType string, ids []int, published bool) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	phpIDs, err := phpValue(ids)
+	if err != nil {
+		return err
+	}
+
+	phpSetPublished := "$entity->setUnpublished()"
+	if published {
+		phpSetPublished = "$entity->setPublished()"
+	}
+	phpCode := "$ids = " + phpIDs + "; " +
+		"$entities = \\Drupal::entityTypeManager()->getStorage(" + phpString(entityType) + ")->loadMultiple($ids); " +
+		"foreach ($ids as $id) { " +
+		"if (!isset($entities[$id])) { print json_encode(array('result' => 'missing', 'id' => $id)); return; } " +
+		"if (!$entities[$id] instanceof \\Drupal\\Core\\Entity\\EntityPublishedInterface) { print json_encode(array('result' => 'unpublishable', 'id' => $id)); return; } " +
+		"} " +
+		"$transaction = \\Drupal::database()->startTransaction(); " +
+		"try { foreach ($entities as $entity) { " + phpSetPublished + "->save(); } } catch (\\Exception $e) { $transaction->rollBack(); throw $e; } " +
+		"unset($transaction); " +
+		"print json_encode(array('result' => 'ok', 'id' => 0));"
+
+	var result struct {
+		Result string `json:"result"`
+		ID     int    `json:"id"`
+	}
+	err = s.phpEval(phpCode, &result)
+	if err != nil {
+		return err
+	}
+	switch result.Result {
+	case "missing":
+		return errors.Wrapf(ErrEntityNotFound, "Could not load %v %v", entityType, result.ID)
+	case "unpublishable":
+		return errors.Newf("%v %v cannot be published or unpublished", entityType, result.ID)
+	}
+	return nil
+}
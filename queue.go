@@ -0,0 +1,43 @@
+package drupal
+
+import (
+	"github.com/phayes/errors"
+)
+
+// QueueWorker is a queue worker plugin definition
+type QueueWorker struct {
+	ID        string                 `json:"id"`
+	Title     string                 `json:"title"`
+	Cron      map[string]interface{} `json:"cron"`       // Cron settings (eg "time"), or nil if the worker is not run on cron
+	QueueName string                 `json:"queue_name"` // The name of the queue processed by the worker
+}
+
+// phpQueueWorker is php code defining a $queueWorker closure that formats a plugin definition as a QueueWorker
+const phpQueueWorker = "$queueWorker = function ($id, $definition) { return array('id' => $id, 'title' => (string) $definition['title'], 'cron' => isset($definition['cron']) ? (object) $definition['cron'] : NULL, 'queue_name' => $id); }; "
+
+// GetQueueWorkers gets the definitions of all registered queue worker plugins
+func (s Site) GetQueueWorkers() ([]QueueWorker, error) {
+	phpCode := phpQueueWorker + "$workers = array(); foreach (\\Drupal::service('plugin.manager.queue_worker')->getDefinitions() as $id => $definition) { $workers[] = $queueWorker($id, $definition); } print json_encode($workers);"
+
+	var workers []QueueWorker
+	err := s.phpEval(phpCode, &workers)
+	if err != nil {
+		return nil, err
+	}
+	return workers, nil
+}
+
+// GetQueueWorker gets the definition of a single queue worker plugin
+func (s Site) GetQueueWorker(id string) (*QueueWorker, error) {
+	phpCode := phpQueueWorker + "$definition = \\Drupal::service('plugin.manager.queue_worker')->getDefinition(" + phpString(id) + ", FALSE); print json_encode($definition ? $queueWorker(" + phpString(id) + ", $definition) : NULL);"
+
+	var worker *QueueWorker
+	err := s.phpEval(phpCode, &worker)
+	if err != nil {
+		return nil, err
+	}
+	if worker == nil {
+		return nil, errors.Newf("Queue worker %v not found", id)
+	}
+	return worker, nil
+}
@@ -0,0 +1,97 @@
+package drupal
+
+import (
+	"strconv"
+)
+
+// Redirect is a path redirect provided by the redirect module
+type Redirect struct {
+	ID               int    `json:"id"`
+	RedirectSource   string `json:"redirect_source"`   // The source path, including any query string
+	RedirectRedirect string `json:"redirect_redirect"` // The target URI, such as "internal:/node/1", "entity:node/1" or an external URL
+	StatusCode       int    `json:"status_code"`
+	Language         string `json:"language"`
+}
+
+// phpRedirectJSON is a php closure that converts a redirect entity to an array matching Redirect
+const phpRedirectJSON = "$redirectJSON = function ($redirect) { return array('id' => (int) $redirect->id(), 'redirect_source' => $redirect->getSourcePathWithQuery(), 'redirect_redirect' => (string) $redirect->get('redirect_redirect')->uri, 'status_code' => (int) $redirect->getStatusCode(), 'language' => $redirect->language()->getId()); }; "
+
+// GetRedirectCount gets the number of redirects.
+// Returns ErrModuleNotEnabled if the redirect module is not enabled.
+func (s Site) GetRedirectCount() (int, error) {
+	err := s.requireModule("redirect")
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	err = s.phpEval("print json_encode((int) \\Drupal::entityTypeManager()->getStorage('redirect')->getQuery()->accessCheck(FALSE)->count()->execute());", &count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetRedirects gets redirects ordered by ID. A limit of 0 gets all redirects after offset.
+// Returns ErrModuleNotEnabled if the redirect module is not enabled.
+func (s Site) GetRedirects(limit, offset int) ([]Redirect, error) {
+	err := s.requireModule("redirect")
+	if err != nil {
+		return nil, err
+	}
+
+	phpCode := phpRedirectJSON +
+		"$storage = \\Drupal::entityTypeManager()->getStorage('redirect'); " +
+		"$query = $storage->getQuery()->accessCheck(FALSE)->sort('rid'); "
+	if limit > 0 {
+		phpCode += "$query->range(" + strconv.Itoa(offset) + ", " + strconv.Itoa(limit) + "); "
+	} else if offset > 0 {
+		phpCode += "$query->range(" + strconv.Itoa(offset) + ", PHP_INT_MAX); "
+	}
+	phpCode += "print json_encode(array_values(array_map($redirectJSON, $storage->loadMultiple($query->execute()))));"
+
+	var redirects []Redirect
+	err = s.phpEval(phpCode, &redirects)
+	if err != nil {
+		return nil, err
+	}
+	return redirects, nil
+}
+
+// GetBrokenRedirects finds up to limit redirects whose target is an internal path or entity that does not exist. A limit of 0 finds all broken redirects.
+// External targets are not checked.
+// Returns ErrModuleNotEnabled if the redirect module is not enabled.
+func (s Site) GetBrokenRedirects(limit int) ([]Redirect, error) {
+	err := s.requireModule("redirect")
+	if err != nil {
+		return nil, err
+	}
+
+	phpCode := phpRedirectJSON +
+		"$isBroken = function ($redirect) { " +
+		"try { " +
+		"$url = $redirect->getRedirectUrl(); " +
+		"if ($url->isExternal()) { return FALSE; } " +
+		"if (!$url->isRouted()) { return strpos($redirect->get('redirect_redirect')->uri, 'internal:') === 0; } " +
+		"return !\\Drupal::service('path.validator')->getUrlIfValidWithoutAccessCheck('/' . $url->getInternalPath()); " +
+		"} catch (\\Exception $e) { return TRUE; } " +
+		"}; " +
+		"$storage = \\Drupal::entityTypeManager()->getStorage('redirect'); " +
+		"$limit = " + strconv.Itoa(limit) + "; " +
+		"$broken = array(); " +
+		"foreach (array_chunk($storage->getQuery()->accessCheck(FALSE)->sort('rid')->execute(), 100) as $ids) { " +
+		"foreach ($storage->loadMultiple($ids) as $redirect) { " +
+		"if ($isBroken($redirect)) { $broken[] = $redirectJSON($redirect); } " +
+		"if ($limit > 0 && count($broken) >= $limit) { break 2; } " +
+		"} " +
+		"$storage->resetCache($ids); " +
+		"} " +
+		"print json_encode($broken);"
+
+	var redirects []Redirect
+	err = s.phpEval(phpCode, &redirects)
+	if err != nil {
+		return nil, err
+	}
+	return redirects, nil
+}
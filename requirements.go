@@ -0,0 +1,78 @@
+package drupal
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/phayes/errors"
+)
+
+// Severity levels of status report requirements, matching drupal's REQUIREMENT_* constants
+const (
+	SeverityInfo    = -1
+	SeverityOK      = 0
+	SeverityWarning = 1
+	SeverityError   = 2
+)
+
+// StatusAlert is a single requirement from drupal's status report
+type StatusAlert struct {
+	Title       string
+	Value       string
+	Description string
+	Severity    int // One of the Severity constants
+}
+
+// GetAlertMessages gets the status report requirements with at least the given severity, using "drush core-requirements"
+func (s Site) GetAlertMessages(minSeverity int) ([]StatusAlert, error) {
+	output, _, errs := s.Drush("core-requirements", "--format=json")
+	if errs != nil {
+		return nil, errs
+	}
+
+	var requirements map[string]struct {
+		Title       string      `json:"title"`
+		Value       interface{} `json:"value"`
+		Description string      `json:"description"`
+		SID         json.Number `json:"sid"`
+	}
+	err := json.Unmarshal([]byte(output), &requirements)
+	if err != nil {
+		return nil, errors.Wraps(err, "Error reading drupal status report")
+	}
+
+	names := []string{}
+	for name := range requirements {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	alerts := []StatusAlert{}
+	for _, name := range names {
+		requirement := requirements[name]
+		severity, err := requirement.SID.Int64()
+		if err != nil {
+			severity = SeverityOK
+		}
+		if int(severity) < minSeverity {
+			continue
+		}
+
+		value := ""
+		if requirement.Value != nil {
+			value = fmt.Sprint(requirement.Value)
+		}
+		alerts = append(alerts, StatusAlert{Title: requirement.Title, Value: value, Description: requirement.Description, Severity: int(severity)})
+	}
+	return alerts, nil
+}
+
+// HasCriticalAlerts checks if the status report has any errors
+func (s Site) HasCriticalAlerts() (bool, error) {
+	alerts, err := s.GetAlertMessages(SeverityError)
+	if err != nil {
+		return false, err
+	}
+	return len(alerts) > 0, nil
+}
@@ -0,0 +1,165 @@
+package drupal
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/phayes/errors"
+)
+
+// Revision is a single revision of a revisionable entity
+type Revision struct {
+	RevisionID        int
+	EntityID          int
+	RevisionLog       string
+	RevisionTimestamp time.Time
+	RevisionUID       int
+	IsDefaultRevision bool
+}
+
+// revisionJSON is a Revision as it is printed by php
+type revisionJSON struct {
+	RevisionID        int    `json:"revision_id"`
+	EntityID          int    `json:"entity_id"`
+	RevisionLog       string `json:"revision_log"`
+	RevisionTimestamp int64  `json:"revision_timestamp"`
+	RevisionUID       int    `json:"revision_uid"`
+	IsDefaultRevision bool   `json:"is_default_revision"`
+}
+
+// GetEntityRevisions gets all revisions of an entity, oldest first
+func (s Site) GetEntityRevisions(entityType string, id int) ([]Revision, error) {
+	phpCode := phpLoadEntity(entityType, id) +
+		"$storage = \\Drupal::entityTypeManager()->getStorage(" + phpString(entityType) + "); " +
+		"$ids = $storage->getQuery()->allRevisions()->accessCheck(FALSE)->condition($entity->getEntityType()->getKey('id'), $entity->id())->sort($entity->getEntityType()->getKey('revision'))->execute(); " +
+		"$revisions = array(); " +
+		"foreach (array_keys($ids) as $revisionID) { " +
+		"$revision = $storage->loadRevision($revisionID); " +
+		"$revisions[] = array('revision_id' => (int) $revision->getRevisionId(), 'entity_id' => (int) $revision->id(), 'revision_log' => (string) $revision->getRevisionLogMessage(), 'revision_timestamp' => (int) $revision->getRevisionCreationTime(), 'revision_uid' => (int) $revision->getRevisionUserId(), 'is_default_revision' => $revision->isDefaultRevision()); " +
+		"} " +
+		"print json_encode($revisions);"
+
+	var revisionsJSON *[]revisionJSON
+	err := s.phpEval(phpCode, &revisionsJSON)
+	if err != nil {
+		return nil, err
+	}
+	if revisionsJSON == nil {
+		return nil, errors.Wrapf(ErrEntityNotFound, "Could not load %v %v", entityType, id)
+	}
+
+	revisions := []Revision{}
+	for _, revision := range *revisionsJSON {
+		revisions = append(revisions, Revision{
+			RevisionID:        revision.RevisionID,
+			EntityID:          revision.EntityID,
+			RevisionLog:       revision.RevisionLog,
+			RevisionTimestamp: time.Unix(revision.RevisionTimestamp, 0),
+			RevisionUID:       revision.RevisionUID,
+			IsDefaultRevision: revision.IsDefaultRevision,
+		})
+	}
+	return revisions, nil
+}
+
+// GetLatestRevision gets the most recent revision of an entity, which may not be the default revision
+func (s Site) GetLatestRevision(entityType string, id int) (*Revision, error) {
+	revisions, err := s.GetEntityRevisions(entityType, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 {
+		return nil, errors.Newf("No revisions found for %v %v", entityType, id)
+	}
+	return &revisions[len(revisions)-1], nil
+}
+
+// RevertToRevision reverts an entity to a previous revision.
+// As with reverting in the drupal UI, this saves a copy of the old revision as a new default revision.
+func (s Site) RevertToRevision(entityType string, id, revisionID int) error {
+	phpCode := "$revision = \\Drupal::entityTypeManager()->getStorage(" + phpString(entityType) + ")->loadRevision(" + strconv.Itoa(revisionID) + "); " +
+		"if (!$revision || $revision->id() != " + strconv.Itoa(id) + ") { print json_encode(FALSE); return; } " +
+		"$revision->setNewRevision(TRUE); $revision->isDefaultRevision(TRUE); " +
+		"$revision->setRevisionLogMessage('Reverted to revision ' . $revision->getRevisionId()); $revision->setRevisionCreationTime(\\Drupal::time()->getRequestTime()); " +
+		"$revision->save(); " +
+		"print json_encode(TRUE);"
+
+	var reverted bool
+	err := s.phpEval(phpCode, &reverted)
+	if err != nil {
+		return err
+	}
+	if !reverted {
+		return errors.Wrapf(ErrEntityNotFound, "Could not load revision %v of %v %v", revisionID, entityType, id)
+	}
+	return nil
+}
+
+// GetEntityRevisionCount gets the number of revisions of an entity
+func (s Site) GetEntityRevisionCount(entityType string, id int) (int, error) {
+	phpCode := phpLoadEntity(entityType, id) +
+		"print json_encode((int) \\Drupal::entityTypeManager()->getStorage(" + phpString(entityType) + ")->getQuery()->allRevisions()->accessCheck(FALSE)->condition($entity->getEntityType()->getKey('id'), $entity->id())->count()->execute());"
+
+	var count *int
+	err := s.phpEval(phpCode, &count)
+	if err != nil {
+		return 0, err
+	}
+	if count == nil {
+		return 0, errors.Wrapf(ErrEntityNotFound, "Could not load %v %v", entityType, id)
+	}
+	return *count, nil
+}
+
+// GetEntitiesWithMostRevisions gets the entities of a type with the most revisions, most revisions first.
+// Revisions are counted in the entity type's revision table directly.
+func (s Site) GetEntitiesWithMostRevisions(entityType string, limit int) ([]struct{ ID, Count int }, error) {
+	phpCode := "$definition = \\Drupal::entityTypeManager()->getDefinition(" + phpString(entityType) + "); " +
+		"if (!$definition->isRevisionable()) { print json_encode(NULL); return; } " +
+		"$query = \\Drupal::database()->select($definition->getRevisionTable(), 'r'); " +
+		"$query->addField('r', $definition->getKey('id'), 'id'); " +
+		"$query->addExpression('COUNT(*)', 'count'); " +
+		"$query->groupBy('r.' . $definition->getKey('id'))->orderBy('count', 'DESC'); "
+	if limit > 0 {
+		phpCode += "$query->range(0, " + strconv.Itoa(limit) + "); "
+	}
+	phpCode += "print json_encode(array_map(function ($row) { return array('id' => (int) $row->id, 'count' => (int) $row->count); }, $query->execute()->fetchAll()));"
+
+	var entities *[]struct{ ID, Count int }
+	err := s.phpEval(phpCode, &entities)
+	if err != nil {
+		return nil, err
+	}
+	if entities == nil {
+		return nil, errors.Newf("%v entities are not revisionable", entityType)
+	}
+	return *entities, nil
+}
+
+// DeleteOldRevisions deletes all but the keep most recent revisions of an entity, returning the number of revisions deleted.
+// The default revision is never deleted, even if it is not one of the most recent.
+func (s Site) DeleteOldRevisions(entityType string, id, keep int) (int, error) {
+	if keep < 0 {
+		return 0, errors.Newf("Invalid number of revisions to keep %v", keep)
+	}
+
+	phpCode := phpLoadEntity(entityType, id) +
+		"$storage = \\Drupal::entityTypeManager()->getStorage(" + phpString(entityType) + "); " +
+		"$ids = array_keys($storage->getQuery()->allRevisions()->accessCheck(FALSE)->condition($entity->getEntityType()->getKey('id'), $entity->id())->sort($entity->getEntityType()->getKey('revision'), 'DESC')->execute()); " +
+		"$deleted = 0; " +
+		"foreach (array_slice($ids, " + strconv.Itoa(keep) + ") as $revisionID) { " +
+		"if ($revisionID == $entity->getRevisionId()) { continue; } " +
+		"$storage->deleteRevision($revisionID); $deleted++; " +
+		"} " +
+		"print json_encode($deleted);"
+
+	var deleted *int
+	err := s.phpEval(phpCode, &deleted)
+	if err != nil {
+		return 0, err
+	}
+	if deleted == nil {
+		return 0, errors.Wrapf(ErrEntityNotFound, "Could not load %v %v", entityType, id)
+	}
+	return *deleted, nil
+}
@@ -0,0 +1,63 @@
+package drupal
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/phayes/errors"
+)
+
+// GetRobotsTxt gets the contents of the site's robots.txt.
+// If the robotstxt module is enabled it is read from the robotstxt.settings config, otherwise from the static robots.txt file.
+func (s Site) GetRobotsTxt() (string, error) {
+	enabled, err := s.moduleEnabled("robotstxt")
+	if err != nil {
+		return "", err
+	}
+	if enabled {
+		var settings struct {
+			Content string `json:"content"`
+		}
+		err = s.getConfig("robotstxt.settings", &settings)
+		if err != nil {
+			return "", err
+		}
+		return settings.Content, nil
+	}
+
+	path, err := s.GetRobotsTxtPath()
+	if err != nil {
+		return "", err
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", errors.Wraps(err, "Error reading robots.txt")
+	}
+	return string(content), nil
+}
+
+// SetRobotsTxt sets the contents of robots.txt in the robotstxt.settings config.
+// Returns ErrModuleNotEnabled if the robotstxt module is not enabled.
+func (s Site) SetRobotsTxt(content string) error {
+	err := s.requireModule("robotstxt")
+	if err != nil {
+		return err
+	}
+	return s.setConfig("robotstxt.settings", "content", content)
+}
+
+// GetRobotsTxtPath gets the filesystem path of the static robots.txt file in the drupal root
+func (s Site) GetRobotsTxtPath() (string, error) {
+	status, err := s.GetStatus()
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(status.Root, "robots.txt")
+	_, err = os.Stat(path)
+	if err != nil {
+		return "", errors.Wraps(err, "Error finding robots.txt")
+	}
+	return path, nil
+}
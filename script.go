@@ -0,0 +1,96 @@
+package drupal
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/phayes/errors"
+)
+
+// RunPHPScript runs a php file in the context of the bootstrapped drupal site using "drush php-script", returning it's output
+func (s Site) RunPHPScript(filePath string) (string, error) {
+	path, err := filepath.Abs(filePath)
+	if err != nil {
+		return "", errors.Wrapf(err, "Could not determine absolute path of %v", filePath)
+	}
+
+	output, _, errs := s.Drush("php-script", path)
+	if errs != nil {
+		return "", errs
+	}
+	return output, nil
+}
+
+// RunPHPCode writes php code to a temporary file and runs it with RunPHPScript, returning it's output.
+// The code may omit the opening <?php tag.
+func (s Site) RunPHPCode(code string) (string, error) {
+	file, err := ioutil.TempFile("", "go-drupal-*.php")
+	if err != nil {
+		return "", errors.Wraps(err, "Error creating php script")
+	}
+	defer os.Remove(file.Name())
+
+	if !strings.HasPrefix(strings.TrimSpace(code), "<?php") {
+		code = "<?php\n" + code
+	}
+	_, err = file.WriteString(code)
+	file.Close()
+	if err != nil {
+		return "", errors.Wraps(err, "Error writing php script")
+	}
+
+	return s.RunPHPScript(file.Name())
+}
+
+// GetDrushScripts lists the drush scripts in scriptPath using "drush php-script" with no script.
+// A relative scriptPath is relative to the site directory.
+func (s Site) GetDrushScripts(scriptPath string) ([]string, error) {
+	output, _, errs := s.Drush("php-script", "--script-path="+s.drushScriptPath(scriptPath))
+	if errs != nil {
+		return nil, errs
+	}
+
+	scripts := []string{}
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			scripts = append(scripts, line)
+		}
+	}
+	return scripts, nil
+}
+
+// RunDrushScript runs a drush script in scriptPath using "drush php-script", passing args as positional key=value arguments after the script name, sorted by key.
+// A relative scriptPath is relative to the site directory.
+// Drush scripts run in the context of the bootstrapped drupal site, so have access to the full drupal API.
+// The arguments can be read in the script with drush_shift() in drush 8, or from $extra in later versions.
+// Positional arguments are used rather than --define=key=value options because php-script does not accept --define in every drush version,
+// and --key=value options can collide with drush's own global options.
+func (s Site) RunDrushScript(scriptPath string, name string, args map[string]string) (string, DrushMessages, error) {
+	keys := []string{}
+	for key := range args {
+		if key == "" || strings.HasPrefix(key, "-") || strings.Contains(key, "=") {
+			return "", nil, errors.Newf("Invalid drush script argument name %v", key)
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	arguments := []string{name}
+	for _, key := range keys {
+		arguments = append(arguments, key+"="+args[key])
+	}
+	arguments = append(arguments, "--script-path="+s.drushScriptPath(scriptPath))
+	return s.Drush("php-script", arguments...)
+}
+
+// drushScriptPath gets the absolute path of a drush script directory, relative to the site directory
+func (s Site) drushScriptPath(scriptPath string) string {
+	if filepath.IsAbs(scriptPath) {
+		return scriptPath
+	}
+	return filepath.Join(string(s), scriptPath)
+}
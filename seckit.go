@@ -0,0 +1,184 @@
+package drupal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/phayes/errors"
+)
+
+// CSPDirectives are the source lists of a Content Security Policy
+type CSPDirectives struct {
+	DefaultSrc []string
+	ScriptSrc  []string
+	StyleSrc   []string
+	ImgSrc     []string
+	ConnectSrc []string
+}
+
+// GetCspPolicy gets the site's Content Security Policy.
+// If the seckit module is enabled and it's CSP is turned on, the policy is read from the seckit.settings config.
+// Otherwise the Content-Security-Policy header of the front page, fetched from the site URI, is parsed.
+// Returns ErrModuleNotEnabled if the seckit module is not enabled and the front page has no policy.
+func (s Site) GetCspPolicy() (*CSPDirectives, error) {
+	enabled, err := s.moduleEnabled("seckit")
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		var settings struct {
+			XSS struct {
+				CSP map[string]interface{} `json:"csp"`
+			} `json:"seckit_xss"`
+		}
+		err = s.getConfig("seckit.settings", &settings)
+		if err != nil {
+			return nil, err
+		}
+		if on, _ := settings.XSS.CSP["checkbox"].(bool); on {
+			directives := map[string]string{}
+			for name, value := range settings.XSS.CSP {
+				if str, ok := value.(string); ok {
+					directives[name] = str
+				}
+			}
+			return cspFromDirectives(directives), nil
+		}
+	}
+
+	url, err := s.siteURL("")
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error fetching %v", url)
+	}
+	resp.Body.Close()
+
+	header := resp.Header.Get("Content-Security-Policy")
+	if header == "" {
+		if !enabled {
+			return nil, errors.Wraps(ErrModuleNotEnabled, "seckit module is not enabled and the site sends no Content-Security-Policy header")
+		}
+		return nil, errors.New("seckit Content Security Policy is turned off and the site sends no Content-Security-Policy header")
+	}
+	return parseCSP(header), nil
+}
+
+// parseCSP parses a Content-Security-Policy header
+func parseCSP(header string) *CSPDirectives {
+	directives := map[string]string{}
+	for _, directive := range strings.Split(header, ";") {
+		parts := strings.SplitN(strings.TrimSpace(directive), " ", 2)
+		if parts[0] == "" {
+			continue
+		}
+		name := strings.ToLower(parts[0])
+		if _, ok := directives[name]; ok {
+			continue // Only the first occurrence of a directive is used
+		}
+		if len(parts) == 2 {
+			directives[name] = parts[1]
+		} else {
+			directives[name] = ""
+		}
+	}
+	return cspFromDirectives(directives)
+}
+
+// cspFromDirectives builds CSPDirectives from directive names mapped to space separated source lists
+func cspFromDirectives(directives map[string]string) *CSPDirectives {
+	return &CSPDirectives{
+		DefaultSrc: strings.Fields(directives["default-src"]),
+		ScriptSrc:  strings.Fields(directives["script-src"]),
+		StyleSrc:   strings.Fields(directives["style-src"]),
+		ImgSrc:     strings.Fields(directives["img-src"]),
+		ConnectSrc: strings.Fields(directives["connect-src"]),
+	}
+}
+
+// XSSPolicy are the cross-site scripting protection headers configured by the seckit module
+type XSSPolicy struct {
+	XFrameOptions      string // "SAMEORIGIN", "DENY", "ALLOW-FROM <uri>" or "" if not sent
+	XSSProtection      string // "0", "1", "1; mode=block" or "" if not sent
+	ContentTypeNoSniff bool   // Drupal core always sends "X-Content-Type-Options: nosniff", so this is always true
+	ReferrerPolicy     string // "" if not sent
+}
+
+// seckitXFrameOptions maps seckit's x_frame setting to X-Frame-Options header values
+var seckitXFrameOptions = map[string]string{"0": "", "1": "SAMEORIGIN", "2": "DENY", "3": "ALLOW-FROM"}
+
+// seckitXSSProtection maps seckit's x_xss select setting to X-XSS-Protection header values
+var seckitXSSProtection = map[string]string{"0": "", "1": "0", "2": "1", "3": "1; mode=block"}
+
+// GetXSSPolicy gets the cross-site scripting protection headers configured in the seckit.settings config.
+// Returns ErrModuleNotEnabled if the seckit module is not enabled.
+func (s Site) GetXSSPolicy() (*XSSPolicy, error) {
+	err := s.requireModule("seckit")
+	if err != nil {
+		return nil, err
+	}
+
+	var settings struct {
+		XSS struct {
+			XXSS struct {
+				Select interface{} `json:"select"`
+			} `json:"x_xss"`
+		} `json:"seckit_xss"`
+		Clickjacking struct {
+			XFrame          interface{} `json:"x_frame"`
+			XFrameAllowFrom string      `json:"x_frame_allow_from"`
+		} `json:"seckit_clickjacking"`
+		Various struct {
+			ReferrerPolicy       bool   `json:"referrer_policy"`
+			ReferrerPolicyPolicy string `json:"referrer_policy_policy"`
+		} `json:"seckit_various"`
+	}
+	err = s.getConfig("seckit.settings", &settings)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := XSSPolicy{
+		XFrameOptions:      seckitXFrameOptions[fmt.Sprint(settings.Clickjacking.XFrame)],
+		XSSProtection:      seckitXSSProtection[fmt.Sprint(settings.XSS.XXSS.Select)],
+		ContentTypeNoSniff: true,
+	}
+	if policy.XFrameOptions == "ALLOW-FROM" {
+		policy.XFrameOptions += " " + settings.Clickjacking.XFrameAllowFrom
+	}
+	if settings.Various.ReferrerPolicy {
+		policy.ReferrerPolicy = settings.Various.ReferrerPolicyPolicy
+	}
+	return &policy, nil
+}
+
+// SetXFrameOptions sets the X-Frame-Options header sent by the seckit module.
+// value is one of "SAMEORIGIN", "DENY", "ALLOW-FROM <uri>", or "" to not send the header.
+// Returns ErrModuleNotEnabled if the seckit module is not enabled.
+func (s Site) SetXFrameOptions(value string) error {
+	err := s.requireModule("seckit")
+	if err != nil {
+		return err
+	}
+
+	option := strings.ToUpper(strings.SplitN(value, " ", 2)[0])
+	for setting, header := range seckitXFrameOptions {
+		if header != option {
+			continue
+		}
+		if option == "ALLOW-FROM" {
+			parts := strings.SplitN(value, " ", 2)
+			if len(parts) != 2 || strings.TrimSpace(parts[1]) == "" {
+				return errors.New("ALLOW-FROM X-Frame-Options requires a URI")
+			}
+			err = s.setConfig("seckit.settings", "seckit_clickjacking.x_frame_allow_from", strings.TrimSpace(parts[1]))
+			if err != nil {
+				return err
+			}
+		}
+		return s.setConfig("seckit.settings", "seckit_clickjacking.x_frame", setting)
+	}
+	return errors.Newf("Invalid X-Frame-Options value %v", value)
+}
@@ -1,5 +1,14 @@
 package drupal
 
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/phayes/errors"
+)
+
 // Settings represents drupal settings defined in $settings of settings.php
 type Settings map[string]interface{}
 
@@ -95,3 +104,178 @@ func (s Settings) GetArray(key string) []string {
 
 	return array
 }
+
+// DumpSettings returns the $settings array defined in settings.php formatted as indented JSON
+func (s Site) DumpSettings() (string, error) {
+	settings, err := s.GetSettings()
+	if err != nil {
+		return "", err
+	}
+
+	out, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return "", errors.Wraps(err, "Error formatting drupal settings")
+	}
+	return string(out), nil
+}
+
+// PrintSettings writes the $settings array defined in settings.php as indented JSON to w
+func (s Site) PrintSettings(w io.Writer) error {
+	dump, err := s.DumpSettings()
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, dump+"\n")
+	if err != nil {
+		return errors.Wraps(err, "Error printing drupal settings")
+	}
+	return nil
+}
+
+// DiffSettings compares the $settings array of this site with that of another site.
+// Each differing key is reported on it's own line, prefixed with "-" for this site's value and "+" for the other site's value.
+// An empty string means the settings are identical.
+func (s Site) DiffSettings(other Site) (string, error) {
+	settings, err := s.GetSettings()
+	if err != nil {
+		return "", err
+	}
+	otherSettings, err := other.GetSettings()
+	if err != nil {
+		return "", err
+	}
+
+	return diffSettings(settings, otherSettings)
+}
+
+func diffSettings(a, b Settings) (string, error) {
+	keys := []string{}
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	diff := ""
+	for _, key := range keys {
+		aval, aok := a[key]
+		bval, bok := b[key]
+
+		ajson, err := json.Marshal(aval)
+		if err != nil {
+			return "", errors.Wrapf(err, "Error comparing drupal settings. Could not encode %v", key)
+		}
+		bjson, err := json.Marshal(bval)
+		if err != nil {
+			return "", errors.Wrapf(err, "Error comparing drupal settings. Could not encode %v", key)
+		}
+		if aok && bok && bytes.Equal(ajson, bjson) {
+			continue
+		}
+
+		if aok {
+			diff += "- " + key + ": " + string(ajson) + "\n"
+		}
+		if bok {
+			diff += "+ " + key + ": " + string(bjson) + "\n"
+		}
+	}
+
+	return diff, nil
+}
+
+// SettingsSchema describes the settings expected to be present, or absent, in settings.php
+type SettingsSchema struct {
+	RequiredKeys   map[string]string // Keys that must be set, mapped to their expected type ("string", "number", "bool", "array", "object"), or "" for any type
+	ProhibitedKeys []string          // Keys that must not be set
+}
+
+// SettingsValidationError describes a single way in which settings do not conform to a SettingsSchema
+type SettingsValidationError struct {
+	Key      string
+	Expected string // The expected type, if any
+	Got      string // The actual type, if the key is set
+	Issue    string // One of "missing", "empty", "wrong type" or "prohibited"
+}
+
+func (sve SettingsValidationError) Error() string {
+	switch sve.Issue {
+	case "wrong type":
+		return sve.Key + ": expected " + sve.Expected + ", got " + sve.Got
+	default:
+		return sve.Key + ": " + sve.Issue
+	}
+}
+
+// DefaultProductionSchema is a SettingsSchema for production sites
+var DefaultProductionSchema = SettingsSchema{
+	RequiredKeys: map[string]string{
+		"hash_salt":             "string",
+		"trusted_host_patterns": "array",
+		"file_private_path":     "string",
+	},
+	ProhibitedKeys: []string{"skip_permissions_hardening"},
+}
+
+// DefaultDevelopmentSchema is a SettingsSchema for development sites
+var DefaultDevelopmentSchema = SettingsSchema{
+	RequiredKeys: map[string]string{
+		"hash_salt": "string",
+	},
+}
+
+// ValidateSchema checks the settings against a schema, returning any problems sorted by key.
+// Required string settings must also not be empty.
+func (s Settings) ValidateSchema(schema SettingsSchema) []SettingsValidationError {
+	problems := []SettingsValidationError{}
+
+	for key, expected := range schema.RequiredKeys {
+		val, ok := s[key]
+		if !ok {
+			problems = append(problems, SettingsValidationError{Key: key, Expected: expected, Issue: "missing"})
+			continue
+		}
+
+		got := settingsType(val)
+		if expected != "" && got != expected {
+			problems = append(problems, SettingsValidationError{Key: key, Expected: expected, Got: got, Issue: "wrong type"})
+		} else if val == "" {
+			problems = append(problems, SettingsValidationError{Key: key, Expected: expected, Got: got, Issue: "empty"})
+		}
+	}
+
+	for _, key := range schema.ProhibitedKeys {
+		val, ok := s[key]
+		if ok {
+			problems = append(problems, SettingsValidationError{Key: key, Got: settingsType(val), Issue: "prohibited"})
+		}
+	}
+
+	sort.Slice(problems, func(i, j int) bool { return problems[i].Key < problems[j].Key })
+	return problems
+}
+
+// settingsType returns the type name of a decoded settings value, as used by SettingsSchema
+func settingsType(val interface{}) string {
+	switch val.(type) {
+	case string:
+		return "string"
+	case float64, int:
+		return "number"
+	case bool:
+		return "bool"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}, Settings:
+		return "object"
+	case nil:
+		return "null"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,396 @@
+package drupal
+
+import (
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/phayes/errors"
+)
+
+// GetSettingsFromFile reads the $settings array from a settings.php file without running php.
+// Only assignments of literal values (strings, numbers, booleans, null and arrays of them) to $settings are understood,
+// such as $settings['key'] = 'value'; or $settings['key']['nested'][] = 'value';. Other statements are ignored,
+// and assignments inside conditionals are always applied. Values are typed as GetSettings would return them.
+func (s Site) GetSettingsFromFile(filePath string) (Settings, error) {
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error reading settings file %v", filePath)
+	}
+	return parseSettingsPHP(string(content)), nil
+}
+
+// parseSettingsPHP extracts literal assignments to $settings from php source
+func parseSettingsPHP(src string) Settings {
+	settings := newPHPArray()
+	for _, statement := range splitPHPStatements(lexPHP(src)) {
+		parseSettingsStatement(statement, settings)
+	}
+
+	converted := phpArrayValue(settings)
+	if values, ok := converted.(map[string]interface{}); ok {
+		return Settings(values)
+	}
+	return Settings{}
+}
+
+type phpTokenType int
+
+const (
+	phpTokenVariable phpTokenType = iota
+	phpTokenString
+	phpTokenNumber
+	phpTokenIdent
+	phpTokenPunct
+	phpTokenOther
+)
+
+// phpToken is a token of php source
+type phpToken struct {
+	Type  phpTokenType
+	Value string
+}
+
+func (t phpToken) is(punct string) bool {
+	return t.Type == phpTokenPunct && t.Value == punct
+}
+
+// lexPHP splits php source into tokens, discarding whitespace, comments and open and close tags.
+// Anything it does not understand becomes a phpTokenOther token.
+func lexPHP(src string) []phpToken {
+	tokens := []phpToken{}
+	isIdent := func(c byte, first bool) bool {
+		return c == '_' || c == '\\' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (!first && c >= '0' && c <= '9')
+	}
+	isDigit := func(c byte) bool {
+		return c >= '0' && c <= '9'
+	}
+
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case strings.HasPrefix(src[i:], "<?php"):
+			i += 5
+		case strings.HasPrefix(src[i:], "?>"):
+			i += 2
+		case strings.HasPrefix(src[i:], "//") || c == '#':
+			end := strings.Index(src[i:], "\n")
+			if end == -1 {
+				end = len(src) - i
+			}
+			i += end
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end == -1 {
+				i = len(src)
+			} else {
+				i += end + 4
+			}
+		case c == '$' && i+1 < len(src) && isIdent(src[i+1], true):
+			j := i + 1
+			for j < len(src) && isIdent(src[j], false) {
+				j++
+			}
+			tokens = append(tokens, phpToken{phpTokenVariable, src[i:j]})
+			i = j
+		case c == '\'' || c == '"':
+			value, interpolated, end := lexPHPString(src, i)
+			if interpolated {
+				tokens = append(tokens, phpToken{phpTokenOther, src[i:end]})
+			} else {
+				tokens = append(tokens, phpToken{phpTokenString, value})
+			}
+			i = end
+		case isDigit(c):
+			j := i
+			for j < len(src) && (isDigit(src[j]) || src[j] == '.' || src[j] == '_' || src[j] == 'e' || src[j] == 'E') {
+				j++
+			}
+			tokens = append(tokens, phpToken{phpTokenNumber, strings.Replace(src[i:j], "_", "", -1)})
+			i = j
+		case isIdent(c, true):
+			j := i
+			for j < len(src) && isIdent(src[j], false) {
+				j++
+			}
+			tokens = append(tokens, phpToken{phpTokenIdent, src[i:j]})
+			i = j
+		case strings.HasPrefix(src[i:], "=>"):
+			tokens = append(tokens, phpToken{phpTokenPunct, "=>"})
+			i += 2
+		case strings.HasPrefix(src[i:], "=="):
+			tokens = append(tokens, phpToken{phpTokenOther, "=="})
+			i += 2
+		case strings.ContainsRune("[](),;={}.-", rune(c)):
+			tokens = append(tokens, phpToken{phpTokenPunct, string(c)})
+			i++
+		default:
+			tokens = append(tokens, phpToken{phpTokenOther, string(c)})
+			i++
+		}
+	}
+	return tokens
+}
+
+// lexPHPString reads the quoted string starting at src[start], returning it's value and the index after the closing quote.
+// interpolated is true if the string is double quoted and contains variables.
+func lexPHPString(src string, start int) (value string, interpolated bool, end int) {
+	quote := src[start]
+	var buf strings.Builder
+	for i := start + 1; i < len(src); i++ {
+		c := src[i]
+		if c == quote {
+			return buf.String(), interpolated, i + 1
+		}
+		if quote == '"' && c == '$' {
+			interpolated = true
+		}
+		if c == '\\' && i+1 < len(src) {
+			next := src[i+1]
+			if quote == '\'' {
+				if next == '\'' || next == '\\' {
+					buf.WriteByte(next)
+					i++
+					continue
+				}
+			} else {
+				escapes := map[byte]byte{'n': '\n', 't': '\t', 'r': '\r', '\\': '\\', '"': '"', '$': '$'}
+				if escaped, ok := escapes[next]; ok {
+					buf.WriteByte(escaped)
+					i++
+					continue
+				}
+			}
+		}
+		buf.WriteByte(c)
+	}
+	return buf.String(), interpolated, len(src)
+}
+
+// splitPHPStatements splits tokens into statements at semicolons and braces
+func splitPHPStatements(tokens []phpToken) [][]phpToken {
+	statements := [][]phpToken{}
+	statement := []phpToken{}
+	for _, token := range tokens {
+		if token.is(";") || token.is("{") || token.is("}") {
+			if len(statement) > 0 {
+				statements = append(statements, statement)
+			}
+			statement = []phpToken{}
+			continue
+		}
+		statement = append(statement, token)
+	}
+	if len(statement) > 0 {
+		statements = append(statements, statement)
+	}
+	return statements
+}
+
+// parseSettingsStatement applies a statement to settings if it assigns a literal value to $settings
+func parseSettingsStatement(statement []phpToken, settings *phpArray) {
+	if len(statement) < 3 || statement[0].Type != phpTokenVariable || statement[0].Value != "$settings" {
+		return
+	}
+
+	// Keys, with nil for an appending []
+	keys := []*string{}
+	i := 1
+	for i < len(statement) && statement[i].is("[") {
+		if i+1 < len(statement) && statement[i+1].is("]") {
+			keys = append(keys, nil)
+			i += 2
+			continue
+		}
+		if i+2 >= len(statement) || !statement[i+2].is("]") {
+			return
+		}
+		key, ok := phpArrayKey(statement[i+1])
+		if !ok {
+			return
+		}
+		keys = append(keys, &key)
+		i += 3
+	}
+	if i >= len(statement) || !statement[i].is("=") {
+		return
+	}
+	value, end, ok := parsePHPValue(statement, i+1)
+	if !ok || end != len(statement) {
+		return
+	}
+
+	if len(keys) == 0 {
+		if array, ok := value.(*phpArray); ok {
+			*settings = *array
+		}
+		return
+	}
+
+	target := settings
+	for _, key := range keys[:len(keys)-1] {
+		if key == nil {
+			child := newPHPArray()
+			target.append(child)
+			target = child
+			continue
+		}
+		child, ok := target.values[*key].(*phpArray)
+		if !ok {
+			child = newPHPArray()
+			target.set(*key, child)
+		}
+		target = child
+	}
+	last := keys[len(keys)-1]
+	if last == nil {
+		target.append(value)
+	} else {
+		target.set(*last, value)
+	}
+}
+
+// parsePHPValue parses a literal value starting at tokens[i], returning the value and the index after it
+func parsePHPValue(tokens []phpToken, i int) (interface{}, int, bool) {
+	if i >= len(tokens) {
+		return nil, i, false
+	}
+	token := tokens[i]
+	switch {
+	case token.Type == phpTokenString:
+		value := token.Value
+		i++
+		for i+1 < len(tokens) && tokens[i].is(".") && tokens[i+1].Type == phpTokenString {
+			value += tokens[i+1].Value
+			i += 2
+		}
+		return value, i, true
+	case token.Type == phpTokenNumber:
+		number, err := strconv.ParseFloat(token.Value, 64)
+		return number, i + 1, err == nil
+	case token.is("-") && i+1 < len(tokens) && tokens[i+1].Type == phpTokenNumber:
+		number, err := strconv.ParseFloat(tokens[i+1].Value, 64)
+		return -number, i + 2, err == nil
+	case token.Type == phpTokenIdent:
+		switch strings.ToLower(token.Value) {
+		case "true":
+			return true, i + 1, true
+		case "false":
+			return false, i + 1, true
+		case "null":
+			return nil, i + 1, true
+		case "array":
+			if i+1 < len(tokens) && tokens[i+1].is("(") {
+				return parsePHPArray(tokens, i+2, ")")
+			}
+		}
+	case token.is("["):
+		return parsePHPArray(tokens, i+1, "]")
+	}
+	return nil, i, false
+}
+
+// parsePHPArray parses the elements of an array literal starting at tokens[i], up to and including the closing token
+func parsePHPArray(tokens []phpToken, i int, closing string) (interface{}, int, bool) {
+	array := newPHPArray()
+	for i < len(tokens) {
+		if tokens[i].is(closing) {
+			return array, i + 1, true
+		}
+
+		value, next, ok := parsePHPValue(tokens, i)
+		if !ok {
+			return nil, i, false
+		}
+		if next < len(tokens) && tokens[next].is("=>") {
+			key, ok := phpArrayKey(tokens[i])
+			if !ok || next != i+1 {
+				return nil, i, false
+			}
+			value, next, ok = parsePHPValue(tokens, next+1)
+			if !ok {
+				return nil, i, false
+			}
+			array.set(key, value)
+		} else {
+			array.append(value)
+		}
+
+		i = next
+		if i < len(tokens) && tokens[i].is(",") {
+			i++
+		} else if i >= len(tokens) || !tokens[i].is(closing) {
+			return nil, i, false
+		}
+	}
+	return nil, i, false
+}
+
+// phpArrayKey converts a literal string or integer token to an array key
+func phpArrayKey(token phpToken) (string, bool) {
+	switch token.Type {
+	case phpTokenString:
+		return token.Value, true
+	case phpTokenNumber:
+		key, err := strconv.Atoi(token.Value)
+		return strconv.Itoa(key), err == nil
+	}
+	return "", false
+}
+
+// phpArray is an ordered php array with string keys
+type phpArray struct {
+	keys   []string
+	values map[string]interface{}
+	next   int
+}
+
+func newPHPArray() *phpArray {
+	return &phpArray{values: map[string]interface{}{}}
+}
+
+func (a *phpArray) set(key string, value interface{}) {
+	if _, ok := a.values[key]; !ok {
+		a.keys = append(a.keys, key)
+	}
+	a.values[key] = value
+	if index, err := strconv.Atoi(key); err == nil && index >= a.next {
+		a.next = index + 1
+	}
+}
+
+func (a *phpArray) append(value interface{}) {
+	a.set(strconv.Itoa(a.next), value)
+}
+
+// phpArrayValue converts parsed values to the types json_encode and json.Unmarshal would produce,
+// so sequential arrays become []interface{} and other arrays map[string]interface{}
+func phpArrayValue(value interface{}) interface{} {
+	array, ok := value.(*phpArray)
+	if !ok {
+		return value
+	}
+
+	sequential := true
+	for i, key := range array.keys {
+		if key != strconv.Itoa(i) {
+			sequential = false
+			break
+		}
+	}
+
+	if sequential {
+		list := []interface{}{}
+		for _, key := range array.keys {
+			list = append(list, phpArrayValue(array.values[key]))
+		}
+		return list
+	}
+	values := map[string]interface{}{}
+	for _, key := range array.keys {
+		values[key] = phpArrayValue(array.values[key])
+	}
+	return values
+}
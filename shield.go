@@ -0,0 +1,66 @@
+package drupal
+
+// ShieldSettings are the HTTP basic authentication settings of the shield module
+type ShieldSettings struct {
+	Enabled     bool   `json:"enabled"`
+	User        string `json:"user"`
+	PasswordSet bool   `json:"password_set"`
+	AllowCLI    bool   `json:"allow_cli"` // Whether command line requests bypass shield
+}
+
+// phpShieldCredentials is php code that loads the editable shield.settings config into $config, and sets $prefix to the prefix of the credential keys.
+// Shield has stored credentials either at the top level of shield.settings, or under "credentials.shield" in later versions.
+// Versions with the "shield_enable" key can be disabled while keeping the credentials, otherwise shield is disabled by an empty user.
+const phpShieldCredentials = "$config = \\Drupal::configFactory()->getEditable('shield.settings'); " +
+	"$prefix = $config->get('credentials') !== NULL ? 'credentials.shield.' : ''; "
+
+// GetShieldStatus gets the shield.settings config.
+// Returns ErrModuleNotEnabled if the shield module is not enabled.
+func (s Site) GetShieldStatus() (*ShieldSettings, error) {
+	err := s.requireModule("shield")
+	if err != nil {
+		return nil, err
+	}
+
+	phpCode := phpShieldCredentials +
+		"$user = (string) $config->get($prefix . 'user'); " +
+		"$enabled = $config->get('shield_enable') !== NULL ? (bool) $config->get('shield_enable') && $user !== '' : $user !== ''; " +
+		"print json_encode(array('enabled' => $enabled, 'user' => $user, 'password_set' => (string) $config->get($prefix . 'pass') !== '', 'allow_cli' => (bool) $config->get('allow_cli')));"
+
+	var settings ShieldSettings
+	err = s.phpEval(phpCode, &settings)
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// EnableShield enables shield with the given credentials.
+// Returns ErrModuleNotEnabled if the shield module is not enabled.
+func (s Site) EnableShield(user, password string) error {
+	err := s.requireModule("shield")
+	if err != nil {
+		return err
+	}
+
+	phpCode := phpShieldCredentials +
+		"if ($prefix) { $config->set('credential_provider', 'shield'); } " +
+		"$config->set($prefix . 'user', " + phpString(user) + ")->set($prefix . 'pass', " + phpString(password) + "); " +
+		"if ($config->get('shield_enable') !== NULL) { $config->set('shield_enable', TRUE); } " +
+		"$config->save();"
+	return s.phpEval(phpCode, nil)
+}
+
+// DisableShield disables shield. Credentials are kept if the installed version of shield supports it.
+// Returns ErrModuleNotEnabled if the shield module is not enabled.
+func (s Site) DisableShield() error {
+	err := s.requireModule("shield")
+	if err != nil {
+		return err
+	}
+
+	phpCode := phpShieldCredentials +
+		"if ($config->get('shield_enable') !== NULL) { $config->set('shield_enable', FALSE); } else { $config->set($prefix . 'user', ''); } " +
+		"$config->save();"
+	return s.phpEval(phpCode, nil)
+}
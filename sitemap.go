@@ -0,0 +1,87 @@
+package drupal
+
+import (
+	"time"
+
+	"github.com/phayes/errors"
+)
+
+// SitemapStatus is the state of the site's XML sitemap
+type SitemapStatus struct {
+	GeneratedAt time.Time // The zero time if the sitemap has not been generated
+	URL         string
+	LinksCount  int
+}
+
+// sitemapModule gets the enabled XML sitemap module, either simple_sitemap or xmlsitemap.
+// Returns ErrModuleNotEnabled if neither module is enabled.
+func (s Site) sitemapModule() (string, error) {
+	for _, module := range []string{"simple_sitemap", "xmlsitemap"} {
+		enabled, err := s.moduleEnabled(module)
+		if err != nil {
+			return "", err
+		}
+		if enabled {
+			return module, nil
+		}
+	}
+	return "", errors.Wraps(ErrModuleNotEnabled, "Neither simple_sitemap nor xmlsitemap module is enabled")
+}
+
+// GetSitemapStatus gets when the XML sitemap was last generated, it's URL and the number of links in it.
+// For simple_sitemap the base URL is read from the simple_sitemap.settings config, falling back to the site URI.
+// Returns ErrModuleNotEnabled if neither the simple_sitemap nor xmlsitemap module is enabled.
+func (s Site) GetSitemapStatus() (*SitemapStatus, error) {
+	module, err := s.sitemapModule()
+	if err != nil {
+		return nil, err
+	}
+
+	phpCode := "$database = \\Drupal::database(); " +
+		"$baseURL = \\Drupal::request()->getSchemeAndHttpHost() . base_path(); "
+	if module == "simple_sitemap" {
+		phpCode += "$configured = \\Drupal::config('simple_sitemap.settings')->get('base_url'); " +
+			"if ($configured) { $baseURL = rtrim($configured, '/') . '/'; } " +
+			"$query = $database->select('simple_sitemap', 's')->condition('status', 1); " +
+			"$query->addExpression('MAX(sitemap_created)', 'generated'); " +
+			"if ($database->schema()->fieldExists('simple_sitemap', 'link_count')) { $query->addExpression('SUM(link_count)', 'links'); } " +
+			"$row = $query->execute()->fetchAssoc(); " +
+			"$generated = (int) $row['generated']; $links = isset($row['links']) ? (int) $row['links'] : 0; "
+	} else {
+		phpCode += "$generated = (int) \\Drupal::state()->get('xmlsitemap_generated_last'); " +
+			"$links = (int) $database->select('xmlsitemap', 'x')->condition('status', 1)->countQuery()->execute()->fetchField(); "
+	}
+	phpCode += "print json_encode(array('generated' => $generated, 'url' => $baseURL . 'sitemap.xml', 'links' => $links));"
+
+	var statusJSON struct {
+		Generated int64  `json:"generated"`
+		URL       string `json:"url"`
+		Links     int    `json:"links"`
+	}
+	err = s.phpEval(phpCode, &statusJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	status := SitemapStatus{URL: statusJSON.URL, LinksCount: statusJSON.Links}
+	if statusJSON.Generated != 0 {
+		status.GeneratedAt = time.Unix(statusJSON.Generated, 0)
+	}
+	return &status, nil
+}
+
+// RegenerateSitemap regenerates the XML sitemap using "drush simple_sitemap-generate" or "drush xmlsitemap-regenerate".
+// Returns ErrModuleNotEnabled if neither the simple_sitemap nor xmlsitemap module is enabled.
+func (s Site) RegenerateSitemap() error {
+	module, err := s.sitemapModule()
+	if err != nil {
+		return err
+	}
+
+	command := "simple_sitemap-generate"
+	if module == "xmlsitemap" {
+		command = "xmlsitemap-regenerate"
+	}
+	_, _, errs := s.Drush(command)
+	return errs
+}
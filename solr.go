@@ -0,0 +1,56 @@
+package drupal
+
+import (
+	"github.com/phayes/errors"
+)
+
+// SolrStatus is the connection status of a Search API Solr server
+type SolrStatus struct {
+	ServerID         string `json:"server_id"`
+	SolrURL          string `json:"solr_url"`
+	SolrVersion      string `json:"solr_version"`
+	Connected        bool   `json:"connected"`
+	IndexedDocuments int    `json:"indexed_documents"` // Number of items indexed on the server, across all of it's indexes
+}
+
+// GetSolrStatus pings a Search API Solr server and gets it's status.
+// Returns ErrModuleNotEnabled if the search_api_solr module is not enabled.
+func (s Site) GetSolrStatus(serverID string) (*SolrStatus, error) {
+	err := s.requireModule("search_api_solr")
+	if err != nil {
+		return nil, err
+	}
+
+	phpCode := "$server = \\Drupal::entityTypeManager()->getStorage('search_api_server')->load(" + phpString(serverID) + "); " +
+		"if (!$server || !($server->getBackend() instanceof \\Drupal\\search_api_solr\\SolrBackendInterface)) { print json_encode(NULL); return; } " +
+		"$connector = $server->getBackend()->getSolrConnector(); " +
+		"$connected = $connector->pingServer() !== FALSE; " +
+		"$indexed = 0; foreach ($server->getIndexes() as $index) { $indexed += $index->getTrackerInstance()->getIndexedItemsCount(); } " +
+		"print json_encode(array('server_id' => $server->id(), 'solr_url' => (string) $connector->getServerUri(), 'solr_version' => $connected ? (string) $connector->getSolrVersion() : '', 'connected' => $connected, 'indexed_documents' => (int) $indexed));"
+
+	var status *SolrStatus
+	err = s.phpEval(phpCode, &status)
+	if err != nil {
+		return nil, err
+	}
+	if status == nil {
+		return nil, errors.Newf("Solr server %v not found", serverID)
+	}
+	return status, nil
+}
+
+// ReindexSolr marks all items in a Search API index for reindexing, then indexes them, using "drush search-api-reindex" and "drush search-api-index".
+// Returns ErrModuleNotEnabled if the search_api_solr module is not enabled.
+func (s Site) ReindexSolr(indexID string) error {
+	err := s.requireModule("search_api_solr")
+	if err != nil {
+		return err
+	}
+
+	_, _, errs := s.Drush("search-api-reindex", indexID)
+	if errs != nil {
+		return errs
+	}
+	_, _, errs = s.Drush("search-api-index", indexID)
+	return errs
+}
@@ -0,0 +1,101 @@
+package drupal
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/phayes/errors"
+)
+
+// NodeStatistic is the view count of a node, as recorded by the statistics module
+type NodeStatistic struct {
+	NID        int
+	TotalCount int
+	DayCount   int
+	MonthCount int // The node_counter table does not track monthly views, so this is always 0 unless a module adds a monthcount column
+	Timestamp  time.Time
+}
+
+// nodeStatisticJSON is a row of the node_counter table as printed by php
+type nodeStatisticJSON struct {
+	NID        int   `json:"nid"`
+	TotalCount int   `json:"totalcount"`
+	DayCount   int   `json:"daycount"`
+	MonthCount int   `json:"monthcount"`
+	Timestamp  int64 `json:"timestamp"`
+}
+
+func (ns nodeStatisticJSON) statistic() NodeStatistic {
+	return NodeStatistic{
+		NID:        ns.NID,
+		TotalCount: ns.TotalCount,
+		DayCount:   ns.DayCount,
+		MonthCount: ns.MonthCount,
+		Timestamp:  time.Unix(ns.Timestamp, 0),
+	}
+}
+
+// phpNodeCounterRow is a php closure that converts a node_counter row to an array of ints
+const phpNodeCounterRow = "$counterRow = function ($row) { return array('nid' => (int) $row->nid, 'totalcount' => (int) $row->totalcount, 'daycount' => (int) $row->daycount, 'monthcount' => isset($row->monthcount) ? (int) $row->monthcount : 0, 'timestamp' => (int) $row->timestamp); }; "
+
+// GetNodeStatistics gets the view counts of a node.
+// Returns ErrModuleNotEnabled if the statistics module is not enabled.
+func (s Site) GetNodeStatistics(nid int) (*NodeStatistic, error) {
+	err := s.requireModule("statistics")
+	if err != nil {
+		return nil, err
+	}
+
+	phpCode := phpNodeCounterRow +
+		"$row = \\Drupal::database()->select('node_counter', 'c')->fields('c')->condition('nid', " + strconv.Itoa(nid) + ")->execute()->fetchObject(); " +
+		"print json_encode($row ? $counterRow($row) : NULL);"
+
+	var row *nodeStatisticJSON
+	err = s.phpEval(phpCode, &row)
+	if err != nil {
+		return nil, err
+	}
+	if row == nil {
+		return nil, errors.Newf("No statistics recorded for node %v", nid)
+	}
+	statistic := row.statistic()
+	return &statistic, nil
+}
+
+// GetTopNodes gets the most viewed nodes, most viewed first. period is one of "day", "month" or "total".
+// Since monthly views are not recorded, "month" ranks nodes viewed in the last 30 days by their total views.
+// Returns ErrModuleNotEnabled if the statistics module is not enabled.
+func (s Site) GetTopNodes(limit int, period string) ([]NodeStatistic, error) {
+	err := s.requireModule("statistics")
+	if err != nil {
+		return nil, err
+	}
+
+	phpCode := phpNodeCounterRow + "$query = \\Drupal::database()->select('node_counter', 'c')->fields('c'); "
+	switch period {
+	case "day":
+		phpCode += "$query->condition('daycount', 0, '>')->orderBy('daycount', 'DESC'); "
+	case "month":
+		phpCode += "$query->condition('timestamp', \\Drupal::time()->getRequestTime() - 30 * 86400, '>=')->orderBy('totalcount', 'DESC'); "
+	case "total":
+		phpCode += "$query->orderBy('totalcount', 'DESC'); "
+	default:
+		return nil, errors.Newf("Invalid statistics period %v", period)
+	}
+	if limit > 0 {
+		phpCode += "$query->range(0, " + strconv.Itoa(limit) + "); "
+	}
+	phpCode += "print json_encode(array_map($counterRow, $query->execute()->fetchAll()));"
+
+	var rows []nodeStatisticJSON
+	err = s.phpEval(phpCode, &rows)
+	if err != nil {
+		return nil, err
+	}
+
+	statistics := []NodeStatistic{}
+	for _, row := range rows {
+		statistics = append(statistics, row.statistic())
+	}
+	return statistics, nil
+}
@@ -0,0 +1,65 @@
+package drupal
+
+import (
+	"strconv"
+)
+
+// Vocabulary is a taxonomy vocabulary
+type Vocabulary struct {
+	VID         string `json:"vid"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Hierarchy   int    `json:"hierarchy"` // 0 for no hierarchy, 1 for single parent, 2 for multiple parents
+	Weight      int    `json:"weight"`
+}
+
+// TaxonomyTerm is a term in a taxonomy vocabulary
+type TaxonomyTerm struct {
+	TID         int    `json:"tid"`
+	VID         string `json:"vid"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Weight      int    `json:"weight"`
+	ParentTIDs  []int  `json:"parent_tids"` // A term at the root of the vocabulary has a single parent of 0
+}
+
+// GetTaxonomyVocabularies gets all taxonomy vocabularies
+func (s Site) GetTaxonomyVocabularies() ([]Vocabulary, error) {
+	phpCode := "$termStorage = \\Drupal::entityTypeManager()->getStorage('taxonomy_term'); " +
+		"$vocabularies = array(); " +
+		"foreach (\\Drupal::entityTypeManager()->getStorage('taxonomy_vocabulary')->loadMultiple() as $vocabulary) { " +
+		"$hierarchy = method_exists($vocabulary, 'getHierarchy') ? $vocabulary->getHierarchy() : $termStorage->getVocabularyHierarchyType($vocabulary->id()); " +
+		"$vocabularies[] = array('vid' => $vocabulary->id(), 'name' => $vocabulary->label(), 'description' => (string) $vocabulary->getDescription(), 'hierarchy' => (int) $hierarchy, 'weight' => (int) $vocabulary->get('weight')); " +
+		"} " +
+		"print json_encode($vocabularies);"
+
+	var vocabularies []Vocabulary
+	err := s.phpEval(phpCode, &vocabularies)
+	if err != nil {
+		return nil, err
+	}
+	return vocabularies, nil
+}
+
+// GetTaxonomyTerms gets the terms in a vocabulary in tree order, down to the given depth. A depth of 0 gets all terms.
+// Terms are loaded as entities and only those the current user has access to view are returned.
+func (s Site) GetTaxonomyTerms(vid string, depth int) ([]TaxonomyTerm, error) {
+	maxDepth := "NULL"
+	if depth > 0 {
+		maxDepth = strconv.Itoa(depth)
+	}
+
+	phpCode := "$terms = array(); " +
+		"foreach (\\Drupal::entityTypeManager()->getStorage('taxonomy_term')->loadTree(" + phpString(vid) + ", 0, " + maxDepth + ", TRUE) as $term) { " +
+		"if (!$term->access('view')) { continue; } " +
+		"$terms[] = array('tid' => (int) $term->id(), 'vid' => $term->bundle(), 'name' => $term->getName(), 'description' => (string) $term->getDescription(), 'weight' => (int) $term->getWeight(), 'parent_tids' => array_map('intval', $term->parents)); " +
+		"} " +
+		"print json_encode($terms);"
+
+	var terms []TaxonomyTerm
+	err := s.phpEval(phpCode, &terms)
+	if err != nil {
+		return nil, err
+	}
+	return terms, nil
+}
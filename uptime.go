@@ -0,0 +1,133 @@
+package drupal
+
+import (
+	"bufio"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/phayes/errors"
+)
+
+// UptimeMetrics are availability statistics for a site
+type UptimeMetrics struct {
+	UptimePercent       float64 // Percentage of requests that did not fail, or 100 if there were no requests
+	TotalRequests       int64
+	FailedRequests      int64   // Requests with a 5xx response status
+	AverageResponseTime float64 // In seconds, or 0 if the log does not record response times
+}
+
+// GetUptimeSLA calculates availability statistics since a time from a web server access log in combined log format, such as "/var/log/nginx/access.log".
+// Drupal 8 does not log requests itself, so the web server's log is used instead.
+// Response times are read from an unquoted number at the end of each line, such as nginx's $request_time, if present.
+func (s Site) GetUptimeSLA(accessLogPath string, since time.Time) (*UptimeMetrics, error) {
+	file, err := os.Open(accessLogPath)
+	if err != nil {
+		return nil, errors.Wraps(err, "Error opening access log")
+	}
+	defer file.Close()
+
+	return parseAccessLog(file, since)
+}
+
+// GetResponseTime measures the round-trip time of an HTTP request to the front page at the site URI.
+// Returns an error if the request takes longer than 30 seconds.
+func (s Site) GetResponseTime() (time.Duration, error) {
+	url, err := s.siteURL("")
+	if err != nil {
+		return 0, err
+	}
+
+	start := time.Now()
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		if isTimeout(err) {
+			return 0, errors.Newf("Timed out fetching %v after %v", url, httpTimeout)
+		}
+		return 0, errors.Wrapf(err, "Error fetching %v", url)
+	}
+	_, err = io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		if isTimeout(err) {
+			return 0, errors.Newf("Timed out reading %v after %v", url, httpTimeout)
+		}
+		return 0, errors.Wrapf(err, "Error reading %v", url)
+	}
+	return time.Since(start), nil
+}
+
+// isTimeout checks if an error from httpClient is caused by the client timeout being exceeded
+func isTimeout(err error) bool {
+	timeout, ok := err.(interface {
+		Timeout() bool
+	})
+	return ok && timeout.Timeout()
+}
+
+// parseAccessLog calculates availability statistics from a combined format access log for requests made at or after since.
+// Malformed lines are skipped.
+func parseAccessLog(r io.Reader, since time.Time) (*UptimeMetrics, error) {
+	metrics := UptimeMetrics{}
+	var timedRequests int64
+	var totalTime float64
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		// Timestamp in [02/Jan/2006:15:04:05 -0700]
+		start := strings.Index(line, "[")
+		end := strings.Index(line, "]")
+		if start == -1 || end < start {
+			continue
+		}
+		timestamp, err := time.Parse("02/Jan/2006:15:04:05 -0700", line[start+1:end])
+		if err != nil || timestamp.Before(since) {
+			continue
+		}
+
+		// Status follows the quoted request line
+		parts := strings.SplitN(line[end+1:], "\"", 3)
+		if len(parts) < 3 {
+			continue
+		}
+		fields := strings.Fields(parts[2])
+		if len(fields) == 0 {
+			continue
+		}
+		status, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+
+		metrics.TotalRequests++
+		if status >= 500 {
+			metrics.FailedRequests++
+		}
+
+		last := fields[len(fields)-1]
+		if len(fields) > 2 && !strings.HasSuffix(last, "\"") {
+			responseTime, err := strconv.ParseFloat(last, 64)
+			if err == nil {
+				timedRequests++
+				totalTime += responseTime
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wraps(err, "Error reading access log")
+	}
+
+	metrics.UptimePercent = 100
+	if metrics.TotalRequests > 0 {
+		metrics.UptimePercent = 100 * float64(metrics.TotalRequests-metrics.FailedRequests) / float64(metrics.TotalRequests)
+	}
+	if timedRequests > 0 {
+		metrics.AverageResponseTime = totalTime / float64(timedRequests)
+	}
+	return &metrics, nil
+}
@@ -0,0 +1,85 @@
+package drupal
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/phayes/errors"
+)
+
+// phpLoadUser returns php code that loads a user into $account, printing null and returning if it does not exist
+func phpLoadUser(uid int) string {
+	return "$account = \\Drupal::entityTypeManager()->getStorage('user')->load(" + strconv.Itoa(uid) + "); if (!$account) { print json_encode(NULL); return; } "
+}
+
+// GetUserLastLogin gets the time a user last logged in.
+// The zero time is returned if the user has never logged in.
+func (s Site) GetUserLastLogin(uid int) (time.Time, error) {
+	var login *int64
+	err := s.phpEval(phpLoadUser(uid)+"print json_encode((int) $account->getLastLoginTime());", &login)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if login == nil {
+		return time.Time{}, errors.Wrapf(ErrUserNotFound, "Could not load user %v", uid)
+	}
+	if *login == 0 {
+		return time.Time{}, nil
+	}
+	return time.Unix(*login, 0), nil
+}
+
+// GetActiveSessionCount gets the number of sessions stored in the sessions table
+func (s Site) GetActiveSessionCount() (int, error) {
+	var count int
+	err := s.phpEval("print json_encode((int) \\Drupal::database()->select('sessions')->countQuery()->execute()->fetchField());", &count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// DeleteUserSessions deletes all sessions belonging to a user, logging them out everywhere
+func (s Site) DeleteUserSessions(uid int) error {
+	return s.phpEval("\\Drupal::service('session_manager')->delete("+strconv.Itoa(uid)+");", nil)
+}
+
+// User is a drupal user account
+type User struct {
+	UID       int
+	Name      string
+	Mail      string
+	Status    bool // true if the account is active, false if blocked
+	Roles     []string
+	Created   time.Time
+	LastLogin time.Time // The zero time if the user has never logged in
+}
+
+// userJSON is a User as it is printed by php
+type userJSON struct {
+	UID       int      `json:"uid"`
+	Name      string   `json:"name"`
+	Mail      string   `json:"mail"`
+	Status    bool     `json:"status"`
+	Roles     []string `json:"roles"`
+	Created   int64    `json:"created"`
+	LastLogin int64    `json:"login"`
+}
+
+func (u userJSON) user() User {
+	user := User{
+		UID:     u.UID,
+		Name:    u.Name,
+		Mail:    u.Mail,
+		Status:  u.Status,
+		Roles:   u.Roles,
+		Created: time.Unix(u.Created, 0),
+	}
+	if u.LastLogin != 0 {
+		user.LastLogin = time.Unix(u.LastLogin, 0)
+	}
+	return user
+}
+
+// phpUserJSON is a php closure that converts a user account to an array matching userJSON
+const phpUserJSON = "$userJSON = function ($account) { return array('uid' => (int) $account->id(), 'name' => $account->getAccountName(), 'mail' => (string) $account->getEmail(), 'status' => $account->isActive(), 'roles' => array_values($account->getRoles()), 'created' => (int) $account->getCreatedTime(), 'login' => (int) $account->getLastLoginTime()); }; "
@@ -0,0 +1,86 @@
+package drupal
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/phayes/errors"
+)
+
+// apacheConfigDirs are the default directories searched for apache virtual host configuration files
+var apacheConfigDirs = []string{"/etc/apache2/sites-enabled", "/etc/httpd/conf.d"}
+
+// nginxConfigDirs are the default directories searched for nginx server configuration files
+var nginxConfigDirs = []string{"/etc/nginx/sites-enabled", "/etc/nginx/conf.d"}
+
+// GetApacheVhostConfig gets the contents of the apache configuration file in dirs with a ServerName or ServerAlias matching domain.
+// If no dirs are given then /etc/apache2/sites-enabled and /etc/httpd/conf.d are searched.
+// Returns an *os.PathError for which os.IsNotExist is true if no virtual host for the domain is found.
+func (s Site) GetApacheVhostConfig(domain string, dirs ...string) (string, error) {
+	if len(dirs) == 0 {
+		dirs = apacheConfigDirs
+	}
+	return findServerConfig(dirs, []string{"servername", "serveralias"}, domain)
+}
+
+// GetNginxServerConfig gets the contents of the nginx configuration file in dirs with a server_name matching domain.
+// If no dirs are given then /etc/nginx/sites-enabled and /etc/nginx/conf.d are searched.
+// Returns an *os.PathError for which os.IsNotExist is true if no server for the domain is found.
+func (s Site) GetNginxServerConfig(domain string, dirs ...string) (string, error) {
+	if len(dirs) == 0 {
+		dirs = nginxConfigDirs
+	}
+	return findServerConfig(dirs, []string{"server_name"}, domain)
+}
+
+// findServerConfig searches the files in dirs for one containing any of the given (lowercase) directives with domain as a value.
+// Missing directories are skipped.
+func findServerConfig(dirs []string, directives []string, domain string) (string, error) {
+	for _, dir := range dirs {
+		files, err := ioutil.ReadDir(dir)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", errors.Wrapf(err, "Error reading %v", dir)
+		}
+
+		for _, file := range files {
+			if file.IsDir() {
+				continue
+			}
+			path := filepath.Join(dir, file.Name())
+			content, err := ioutil.ReadFile(path)
+			if err != nil {
+				return "", errors.Wrapf(err, "Error reading %v", path)
+			}
+			if serverConfigMatches(string(content), directives, domain) {
+				return string(content), nil
+			}
+		}
+	}
+	return "", &os.PathError{Op: "find server configuration", Path: domain, Err: os.ErrNotExist}
+}
+
+// serverConfigMatches checks if a configuration file contains any of the given directives with domain as a value
+func serverConfigMatches(content string, directives []string, domain string) bool {
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(strings.TrimRight(strings.TrimSpace(line), ";"))
+		if len(fields) < 2 {
+			continue
+		}
+		for _, directive := range directives {
+			if strings.ToLower(fields[0]) != directive {
+				continue
+			}
+			for _, value := range fields[1:] {
+				if strings.EqualFold(value, domain) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}